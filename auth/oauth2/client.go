@@ -62,6 +62,23 @@ type Tokens struct {
 	ExpiresAt    time.Time `json:"expires_at,omitempty"`
 }
 
+// String implements fmt.Stringer without exposing token values, so tokens
+// printed with %v or %s (for example in log messages) stay masked.
+func (t Tokens) String() string {
+	return fmt.Sprintf("oauth2.Tokens{AccessToken:%s RefreshToken:%s IDToken:%s TokenType:%s Scope:%s ExpiresAt:%s}",
+		maskToken(t.AccessToken), maskToken(t.RefreshToken), maskToken(t.IDToken), t.TokenType, t.Scope, t.ExpiresAt.Format(time.RFC3339))
+}
+
+// GoString implements fmt.GoStringer so %#v is masked as well.
+func (t Tokens) GoString() string { return t.String() }
+
+func maskToken(v string) string {
+	if v == "" {
+		return `""`
+	}
+	return "[REDACTED]"
+}
+
 // EndpointError describes a rejected upstream token request without exposing
 // the provider response body or OAuth tokens.
 type EndpointError struct {
@@ -76,6 +93,16 @@ func (e *EndpointError) Error() string {
 	return fmt.Sprintf("oauth2: token endpoint returned status %d (%s)", e.StatusCode, e.Code)
 }
 
+// ErrorFields exposes the status and OAuth error code as structured log
+// fields (it satisfies log.ErrorFielder without importing the log package).
+func (e *EndpointError) ErrorFields() map[string]interface{} {
+	fields := map[string]interface{}{"status_code": e.StatusCode}
+	if e.Code != "" {
+		fields["code"] = e.Code
+	}
+	return fields
+}
+
 // Client exchanges authorization codes and refresh tokens for a confidential
 // OAuth client using client_secret_basic authentication.
 type Client struct {
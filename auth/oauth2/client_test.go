@@ -5,6 +5,7 @@ import (
 	"encoding/base64"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"io"
 	"net/http"
 	"net/http/httptest"
@@ -272,3 +273,16 @@ func decodeBasicAuth(t *testing.T, header string) string {
 	}
 	return string(decoded)
 }
+
+func TestTokensStringMasksValues(t *testing.T) {
+	tokens := sdkoauth.Tokens{AccessToken: "access-value", RefreshToken: "refresh-value", TokenType: "Bearer"}
+
+	for _, out := range []string{fmt.Sprintf("%v", tokens), fmt.Sprintf("%+v", tokens), fmt.Sprintf("%#v", tokens), tokens.String()} {
+		if strings.Contains(out, "access-value") || strings.Contains(out, "refresh-value") {
+			t.Fatalf("token leaked: %s", out)
+		}
+		if !strings.Contains(out, "TokenType:Bearer") {
+			t.Fatalf("expected non-secret fields to be kept: %s", out)
+		}
+	}
+}
@@ -0,0 +1,121 @@
+// Package ctxfields holds what the log and metrics packages share to enrich
+// their output from a context: the values recorded for both of them (the
+// tenant id) and the registry of named context extractors.
+package ctxfields
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+
+	"github.com/totvs/go-sdk/trace"
+)
+
+type ctxKey string
+
+const tenantKey ctxKey = "tenant-id"
+
+func init() {
+	trace.RegisterDetachKey(tenantKey)
+}
+
+// ContextWithTenantID returns a context carrying the tenant id, read by both
+// the log fields and the metric attributes.
+func ContextWithTenantID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, tenantKey, id)
+}
+
+// TenantIDFromContext returns the tenant id stored in ctx, if any.
+func TenantIDFromContext(ctx context.Context) string {
+	if ctx == nil {
+		return ""
+	}
+	s, _ := ctx.Value(tenantKey).(string)
+	return s
+}
+
+// Extractor reads values from ctx and reports them through set.
+type Extractor func(ctx context.Context, set func(k string, v any))
+
+// Named is an extractor registered under a name.
+type Named struct {
+	Name string
+	Fn   Extractor
+}
+
+// Registry is an ordered list of named extractors. It holds an immutable
+// []Named replaced on every change, so Run never takes a lock.
+type Registry struct {
+	mu   sync.Mutex
+	list atomic.Pointer[[]Named]
+}
+
+// NewRegistry creates a registry with the given extractors, in order.
+func NewRegistry(extractors ...Named) *Registry {
+	r := &Registry{}
+	r.list.Store(&extractors)
+	return r
+}
+
+// Register adds (or replaces, when name is already registered) an extractor.
+// Extractors run in registration order, so a later extractor overrides the
+// values set by earlier ones. A nil fn removes the extractor.
+func (r *Registry) Register(name string, fn Extractor) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	cur := *r.list.Load()
+	next := make([]Named, 0, len(cur)+1)
+	replaced := false
+	for _, e := range cur {
+		if e.Name != name {
+			next = append(next, e)
+			continue
+		}
+		if fn != nil {
+			next = append(next, Named{name, fn})
+		}
+		replaced = true
+	}
+	if !replaced && fn != nil {
+		next = append(next, Named{name, fn})
+	}
+	r.list.Store(&next)
+}
+
+// Run applies the registered extractors to ctx.
+func (r *Registry) Run(ctx context.Context, set func(k string, v any)) {
+	for _, e := range *r.list.Load() {
+		e.Fn(ctx, set)
+	}
+}
+
+// Save returns a function restoring the current registrations, order
+// included.
+func (r *Registry) Save() (restore func()) {
+	saved := r.list.Load()
+	return func() {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+		r.list.Store(saved)
+	}
+}
+
+// StringValue returns an extractor reporting the non-empty string stored in
+// the context under key as name.
+func StringValue(key any, name string) Extractor {
+	return func(ctx context.Context, set func(string, any)) {
+		if s, ok := ctx.Value(key).(string); ok && s != "" {
+			set(name, s)
+		}
+	}
+}
+
+// TenantID returns an extractor reporting the tenant id (see
+// ContextWithTenantID) as name.
+func TenantID(name string) Extractor {
+	return func(ctx context.Context, set func(string, any)) {
+		if id := TenantIDFromContext(ctx); id != "" {
+			set(name, id)
+		}
+	}
+}
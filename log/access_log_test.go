@@ -0,0 +1,208 @@
+package log_test
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	logger "github.com/totvs/go-sdk/log"
+	adapter "github.com/totvs/go-sdk/log/adapter"
+	middleware "github.com/totvs/go-sdk/log/middleware"
+)
+
+func TestAccessLogCompletionEvent(t *testing.T) {
+	buf := &bytes.Buffer{}
+	opts := middleware.MiddlewareOptions{AccessLog: true, InjectLogger: true}
+	h := middleware.HTTPMiddlewareWithOptions(adapter.NewLog(buf, logger.InfoLevel), opts)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if _, logged := middleware.GetLoggerFromRequest(r); !logged {
+			t.Error("handlers should know the request is access-logged")
+		}
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte("hello"))
+		w.(http.Flusher).Flush()
+	}))
+
+	req := httptest.NewRequest(http.MethodPost, "/items", nil)
+	req.RemoteAddr = "192.0.2.10:4321"
+	req.Header.Set("User-Agent", "curl/8.0")
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+
+	m := findLine(buf.String(), "http request completed")
+	if m == nil {
+		t.Fatalf("expected a completion event, got: %s", buf.String())
+	}
+	if m["status"] != float64(201) || m["bytes"] != float64(5) || m["client_ip"] != "192.0.2.10" ||
+		m["user_agent"] != "curl/8.0" || m["method"] != "POST" || m["path"] != "/items" || m["level"] != "info" {
+		t.Fatalf("unexpected completion fields: %v", m)
+	}
+	if _, ok := m["latency"].(float64); !ok {
+		t.Fatalf("expected latency, got %v", m["latency"])
+	}
+	if !rec.Flushed {
+		t.Fatal("expected Flush to reach the underlying writer")
+	}
+}
+
+func TestAccessLogStatusAfterEarlyHints(t *testing.T) {
+	buf := &bytes.Buffer{}
+	h := middleware.HTTPMiddlewareWithOptions(adapter.NewLog(buf, logger.InfoLevel), middleware.MiddlewareOptions{AccessLog: true})(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Link", "</app.css>; rel=preload; as=style")
+			w.WriteHeader(http.StatusEarlyHints)
+			_, _ = w.Write([]byte("body"))
+		}))
+	srv := httptest.NewServer(h)
+	defer srv.Close()
+
+	resp, err := http.Get(srv.URL)
+	if err != nil {
+		t.Fatalf("request failed: %v", err)
+	}
+	_ = resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("expected the client to receive 200, got %d", resp.StatusCode)
+	}
+	if m := findLine(buf.String(), "http request completed"); m == nil || m["status"] != float64(200) {
+		t.Fatalf("expected status 200 in the access log, got %v", m)
+	}
+}
+
+func TestAccessLogLevelByStatus(t *testing.T) {
+	cases := map[int]string{200: "info", 404: "warn", 503: "error"}
+	for status, level := range cases {
+		buf := &bytes.Buffer{}
+		h := middleware.HTTPMiddlewareWithOptions(adapter.NewLog(buf, logger.InfoLevel), middleware.MiddlewareOptions{AccessLog: true})(
+			http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) { w.WriteHeader(status) }))
+		h.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+		if m := findLine(buf.String(), "http request completed"); m == nil || m["level"] != level {
+			t.Fatalf("status %d: expected level %s, got %v", status, level, m)
+		}
+	}
+}
+
+func TestAccessLogSkipLists(t *testing.T) {
+	buf := &bytes.Buffer{}
+	opts := middleware.MiddlewareOptions{
+		LogRequest:  true,
+		AccessLog:   true,
+		SkipPaths:   []string{"/healthz", "/metrics/*"},
+		SkipHeaders: map[string]string{"User-Agent": "kube-probe/"},
+	}
+	h := middleware.HTTPMiddlewareWithOptions(adapter.NewLog(buf, logger.InfoLevel), opts)(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {}))
+
+	probe := httptest.NewRequest(http.MethodGet, "/ready", nil)
+	probe.Header.Set("User-Agent", "kube-probe/1.29")
+	for _, r := range []*http.Request{
+		httptest.NewRequest(http.MethodGet, "/healthz", nil),
+		httptest.NewRequest(http.MethodGet, "/metrics/prom", nil),
+		probe,
+	} {
+		h.ServeHTTP(httptest.NewRecorder(), r)
+	}
+	if buf.Len() != 0 {
+		t.Fatalf("expected skipped requests not to be logged, got: %s", buf.String())
+	}
+
+	h.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/healthz/deep", nil))
+	if findLine(buf.String(), "http request completed") == nil {
+		t.Fatalf("exact paths must not match by prefix, got: %s", buf.String())
+	}
+}
+
+func TestAccessLogTrustedProxies(t *testing.T) {
+	cases := []struct {
+		remote, xff, want string
+	}{
+		{"203.0.113.7:1000", "198.51.100.1", "203.0.113.7"},         // untrusted peer: header ignored
+		{"10.0.0.2:1000", "198.51.100.1, 10.0.0.3", "198.51.100.1"}, // trusted chain
+		{"10.0.0.2:1000", "1.1.1.1, 198.51.100.1", "198.51.100.1"},  // spoofed left-most hop ignored
+		{"[::ffff:10.0.0.2]:1000", "2001:db8::1", "2001:db8::1"},    // IPv4-mapped peer
+		{"10.0.0.2:1000", "", "10.0.0.2"},                           // no header
+	}
+	for _, tc := range cases {
+		buf := &bytes.Buffer{}
+		opts := middleware.MiddlewareOptions{AccessLog: true, TrustedProxies: []string{"10.0.0.0/8", "invalid"}}
+		h := middleware.HTTPMiddlewareWithOptions(adapter.NewLog(buf, logger.InfoLevel), opts)(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {}))
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.RemoteAddr = tc.remote
+		if tc.xff != "" {
+			req.Header.Set("X-Forwarded-For", tc.xff)
+		}
+		h.ServeHTTP(httptest.NewRecorder(), req)
+		if m := findLine(buf.String(), "http request completed"); m == nil || m["client_ip"] != tc.want {
+			t.Fatalf("remote %s xff %q: expected client_ip %s, got %v", tc.remote, tc.xff, tc.want, m)
+		}
+	}
+}
+
+func TestAccessLogPreservesHijacker(t *testing.T) {
+	var hijackErr error
+	h := middleware.HTTPMiddlewareWithOptions(logger.GetGlobal(), middleware.MiddlewareOptions{AccessLog: true})(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, _, err := http.NewResponseController(w).Hijack()
+		hijackErr = err
+		if conn != nil {
+			_, _ = conn.Write([]byte("HTTP/1.1 204 No Content\r\n\r\n"))
+			_ = conn.Close()
+		}
+	}))
+	srv := httptest.NewServer(h)
+	defer srv.Close()
+
+	resp, err := http.Get(srv.URL)
+	if err != nil {
+		t.Fatalf("request failed: %v", err)
+	}
+	_ = resp.Body.Close()
+	if hijackErr != nil || resp.StatusCode != http.StatusNoContent {
+		t.Fatalf("expected the hijacked connection to be usable, got %v / %d", hijackErr, resp.StatusCode)
+	}
+}
+
+// plainWriter implements only http.ResponseWriter.
+type plainWriter struct{ http.ResponseWriter }
+
+// readerFromWriter counts the bytes received through ReadFrom.
+type readerFromWriter struct {
+	http.ResponseWriter
+	readFrom int64
+}
+
+func (w *readerFromWriter) ReadFrom(src io.Reader) (int64, error) {
+	n, err := io.Copy(w.ResponseWriter, src)
+	w.readFrom += n
+	return n, err
+}
+
+func TestAccessLogExposesOnlyUnderlyingInterfaces(t *testing.T) {
+	opts := middleware.MiddlewareOptions{AccessLog: true}
+	var flushes, hijacks, readsFrom bool
+	h := middleware.HTTPMiddlewareWithOptions(logger.GetGlobal(), opts)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, flushes = w.(http.Flusher)
+		_, hijacks = w.(http.Hijacker)
+		_, readsFrom = w.(io.ReaderFrom)
+	}))
+	h.ServeHTTP(plainWriter{httptest.NewRecorder()}, httptest.NewRequest(http.MethodGet, "/", nil))
+	if flushes || hijacks || readsFrom {
+		t.Fatalf("expected no optional interfaces, got flusher=%v hijacker=%v readerFrom=%v", flushes, hijacks, readsFrom)
+	}
+
+	buf := &bytes.Buffer{}
+	h = middleware.HTTPMiddlewareWithOptions(adapter.NewLog(buf, logger.InfoLevel), opts)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if _, ok := w.(http.Flusher); ok {
+			t.Error("the wrapped writer does not implement http.Flusher")
+		}
+		_, _ = io.Copy(w, struct{ io.Reader }{strings.NewReader("streamed body")})
+	}))
+	dst := &readerFromWriter{ResponseWriter: httptest.NewRecorder()}
+	h.ServeHTTP(dst, httptest.NewRequest(http.MethodGet, "/file", nil))
+	if dst.readFrom != int64(len("streamed body")) {
+		t.Fatalf("expected io.Copy to use ReadFrom, got %d bytes", dst.readFrom)
+	}
+	if m := findLine(buf.String(), "http request completed"); m == nil || m["bytes"] != float64(len("streamed body")) {
+		t.Fatalf("expected the ReadFrom bytes in the access log, got %v", m)
+	}
+}
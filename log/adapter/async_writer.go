@@ -0,0 +1,254 @@
+package adapter
+
+import (
+	"context"
+	"errors"
+	"io"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	mt "github.com/totvs/go-sdk/metrics"
+)
+
+// AsyncDroppedLinesMetric is the counter incremented for every line dropped
+// by an AsyncWriter because its buffer was full.
+const AsyncDroppedLinesMetric = "log_async_dropped_lines_total"
+
+// ErrAsyncWriterClosed is returned by AsyncWriter.Write after Close.
+var ErrAsyncWriterClosed = errors.New("log: async writer closed")
+
+// DropPolicy selects what an AsyncWriter does when its buffer is full.
+type DropPolicy int
+
+const (
+	// DropNewest discards the line being written (the caller never blocks).
+	DropNewest DropPolicy = iota
+	// DropOldest discards the oldest buffered line to make room (the caller never blocks).
+	DropOldest
+	// Block waits until there is room in the buffer (no line is lost).
+	Block
+)
+
+// AsyncWriterOptions configures an AsyncWriter.
+type AsyncWriterOptions struct {
+	// BufferSize is the maximum number of buffered lines (default 1024).
+	BufferSize int
+	// Policy is applied when the buffer is full (default DropNewest).
+	Policy DropPolicy
+	// FlushInterval is how often the destination is flushed when it
+	// implements Sync() error or Flush() error (default 1s; negative disables).
+	FlushInterval time.Duration
+	// Metrics receives the dropped-lines counter. Defaults to metrics.GetGlobal().
+	Metrics mt.MetricsFacade
+}
+
+// AsyncWriter is an io.Writer that copies each line into a bounded ring
+// buffer and writes it to the destination from a background goroutine, so
+// logging never blocks on a slow destination (unless Policy is Block).
+// Call Close during graceful shutdown to drain the buffer.
+type AsyncWriter struct {
+	w       io.Writer
+	wmu     sync.Mutex // serializes writes and flushes of w
+	policy  DropPolicy
+	metrics mt.MetricsFacade
+
+	mu        sync.Mutex
+	changed   *sync.Cond // signals buffer and progress changes
+	ring      [][]byte
+	head      int
+	count     int
+	enqueued  uint64 // lines accepted (including those later dropped by DropOldest)
+	processed uint64 // lines written to the destination or dropped from the buffer
+	closed    bool
+
+	dropped   atomic.Uint64
+	stop      chan struct{}
+	wg        sync.WaitGroup
+	closeOnce sync.Once
+}
+
+// NewAsyncWriter starts an AsyncWriter writing to w.
+func NewAsyncWriter(w io.Writer, opts AsyncWriterOptions) *AsyncWriter {
+	if opts.BufferSize <= 0 {
+		opts.BufferSize = 1024
+	}
+	if opts.FlushInterval == 0 {
+		opts.FlushInterval = time.Second
+	}
+	a := &AsyncWriter{
+		w:       w,
+		policy:  opts.Policy,
+		metrics: opts.Metrics,
+		ring:    make([][]byte, opts.BufferSize),
+		stop:    make(chan struct{}),
+	}
+	a.changed = sync.NewCond(&a.mu)
+
+	a.wg.Add(1)
+	go a.run()
+	if opts.FlushInterval > 0 && flusherOf(w) != nil {
+		a.wg.Add(1)
+		go a.flushPeriodically(opts.FlushInterval)
+	}
+	return a
+}
+
+// Write enqueues a copy of p. It returns len(p) even when the line is
+// dropped, so loggers never treat back pressure as a write error.
+func (a *AsyncWriter) Write(p []byte) (int, error) {
+	line := append([]byte(nil), p...)
+
+	a.mu.Lock()
+	n, drops, err := a.enqueue(line)
+	a.mu.Unlock()
+	a.recordDrops(drops)
+	return n, err
+}
+
+// enqueue stores line in the ring applying the drop policy and returns the
+// number of lines dropped. Must be called with a.mu held.
+func (a *AsyncWriter) enqueue(line []byte) (n, drops int, err error) {
+	for !a.closed && a.count == len(a.ring) {
+		switch a.policy {
+		case DropOldest:
+			a.ring[a.head] = nil
+			a.head = (a.head + 1) % len(a.ring)
+			a.count--
+			a.processed++
+			drops++
+		case Block:
+			a.changed.Wait()
+		default:
+			return len(line), drops + 1, nil
+		}
+	}
+	if a.closed {
+		return 0, drops, ErrAsyncWriterClosed
+	}
+	a.ring[(a.head+a.count)%len(a.ring)] = line
+	a.count++
+	a.enqueued++
+	a.changed.Broadcast()
+	return len(line), drops, nil
+}
+
+// Dropped returns the number of lines dropped because the buffer was full.
+func (a *AsyncWriter) Dropped() uint64 { return a.dropped.Load() }
+
+// Flush blocks until every line accepted before the call has been written,
+// then flushes the destination when it supports it.
+func (a *AsyncWriter) Flush() error {
+	a.mu.Lock()
+	target := a.enqueued
+	for a.processed < target {
+		a.changed.Wait()
+	}
+	a.mu.Unlock()
+	return a.flushDestination()
+}
+
+// Close drains the buffer, stops the background goroutines and flushes the
+// destination. The destination itself is not closed. Further writes return
+// ErrAsyncWriterClosed.
+func (a *AsyncWriter) Close() error {
+	var err error
+	a.closeOnce.Do(func() {
+		a.mu.Lock()
+		a.closed = true
+		a.changed.Broadcast()
+		a.mu.Unlock()
+		close(a.stop)
+		a.wg.Wait()
+		err = a.flushDestination()
+	})
+	return err
+}
+
+// recordDrops counts n dropped lines. It is called without a.mu held, so a
+// slow metrics backend never stalls the writers.
+func (a *AsyncWriter) recordDrops(n int) {
+	if n == 0 {
+		return
+	}
+	a.dropped.Add(uint64(n))
+	mf := a.metrics
+	if mf == nil {
+		mf = mt.GetGlobal()
+	}
+	mf.GetOrCreateCounter(AsyncDroppedLinesMetric, mt.MetricTypeTech, mt.MetricClassInstance).Add(context.Background(), int64(n))
+}
+
+// run writes buffered lines in batches until the writer is closed and drained.
+func (a *AsyncWriter) run() {
+	defer a.wg.Done()
+	batch := make([][]byte, 0, len(a.ring))
+	for {
+		a.mu.Lock()
+		for a.count == 0 && !a.closed {
+			a.changed.Wait()
+		}
+		if a.count == 0 && a.closed {
+			a.mu.Unlock()
+			return
+		}
+		batch = batch[:0]
+		for a.count > 0 {
+			batch = append(batch, a.ring[a.head])
+			a.ring[a.head] = nil
+			a.head = (a.head + 1) % len(a.ring)
+			a.count--
+		}
+		// room was made: wake blocked producers.
+		a.changed.Broadcast()
+		a.mu.Unlock()
+
+		a.wmu.Lock()
+		for _, line := range batch {
+			_, _ = a.w.Write(line)
+		}
+		a.wmu.Unlock()
+
+		a.mu.Lock()
+		a.processed += uint64(len(batch))
+		a.changed.Broadcast()
+		a.mu.Unlock()
+	}
+}
+
+func (a *AsyncWriter) flushPeriodically(interval time.Duration) {
+	defer a.wg.Done()
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			_ = a.flushDestination()
+		case <-a.stop:
+			return
+		}
+	}
+}
+
+// flushDestination flushes w, taking turns with the writes of run since
+// destinations such as *bufio.Writer are not safe for concurrent use.
+func (a *AsyncWriter) flushDestination() error {
+	f := flusherOf(a.w)
+	if f == nil {
+		return nil
+	}
+	a.wmu.Lock()
+	defer a.wmu.Unlock()
+	return f()
+}
+
+// flusherOf returns the Sync or Flush method of w, if any.
+func flusherOf(w io.Writer) func() error {
+	switch f := w.(type) {
+	case interface{ Sync() error }:
+		return f.Sync
+	case interface{ Flush() error }:
+		return f.Flush
+	}
+	return nil
+}
@@ -15,11 +15,14 @@ type logrSink struct {
 	lf     lg.LoggerFacade
 	name   string
 	values map[string]interface{}
+	// named is lf carrying the logger name, used by Enabled to resolve
+	// per-name level overrides without allocating on every call.
+	named lg.LoggerFacade
 }
 
 // NewLogrAdapter cria um novo logr.Logger que delega para o LoggerFacade fornecido.
 func NewLogrAdapter(l lg.LoggerFacade) logr.Logger {
-	return logr.New(&logrSink{lf: l})
+	return logr.New(&logrSink{lf: l, named: l})
 }
 
 // NewGlobalLogr cria um logr.Logger usando o logger global do pacote `log`.
@@ -38,26 +41,50 @@ func InstallGlobalKlog() { InstallKlogLogger(lg.GetGlobal()) }
 
 func (r *logrSink) Init(_ logr.RuntimeInfo) {}
 
-func (r *logrSink) Enabled(_ int) bool { return true }
+// TraceVerbosity is the first logr/klog verbosity mapped to TraceLevel.
+// Following the klog conventions, V(1)..V(4) are debug information and V(5)
+// and above are trace-level details.
+const TraceVerbosity = 5
+
+// VerbosityToLevel maps a logr verbosity to a facade level: V(0) → Info,
+// V(1..4) → Debug and V(5+) → Trace.
+func VerbosityToLevel(v int) lg.Level {
+	switch {
+	case v <= 0:
+		return lg.InfoLevel
+	case v < TraceVerbosity:
+		return lg.DebugLevel
+	default:
+		return lg.TraceLevel
+	}
+}
+
+func (r *logrSink) Enabled(level int) bool {
+	return r.named.Enabled(VerbosityToLevel(level))
+}
 
 func (r *logrSink) Info(level int, msg string, keysAndValues ...any) {
 	fields := r.cloneValues()
 	mergeKV(fields, keysAndValues)
 	if r.name != "" {
-		fields["logger"] = r.name
+		fields[lg.LoggerNameField] = r.name
 	}
-	if level > 0 {
-		r.lf.WithFields(fields).Debug().Msg(msg)
-		return
+	lf := r.lf.WithFields(fields)
+	switch VerbosityToLevel(level) {
+	case lg.TraceLevel:
+		lf.Trace().Msg(msg)
+	case lg.DebugLevel:
+		lf.Debug().Msg(msg)
+	default:
+		lf.Info().Msg(msg)
 	}
-	r.lf.WithFields(fields).Info().Msg(msg)
 }
 
 func (r *logrSink) Error(err error, msg string, keysAndValues ...any) {
 	fields := r.cloneValues()
 	mergeKV(fields, keysAndValues)
 	if r.name != "" {
-		fields["logger"] = r.name
+		fields[lg.LoggerNameField] = r.name
 	}
 	if len(fields) == 0 {
 		r.lf.Error(err).Msg(msg)
@@ -69,7 +96,7 @@ func (r *logrSink) Error(err error, msg string, keysAndValues ...any) {
 func (r *logrSink) WithValues(keysAndValues ...any) logr.LogSink {
 	nv := r.cloneValues()
 	mergeKV(nv, keysAndValues)
-	return &logrSink{lf: r.lf, name: r.name, values: nv}
+	return &logrSink{lf: r.lf, name: r.name, values: nv, named: r.named}
 }
 
 func (r *logrSink) WithName(name string) logr.LogSink {
@@ -79,7 +106,7 @@ func (r *logrSink) WithName(name string) logr.LogSink {
 	} else {
 		newName = r.name + "/" + name
 	}
-	return &logrSink{lf: r.lf, name: newName, values: r.cloneValues()}
+	return &logrSink{lf: r.lf, name: newName, values: r.cloneValues(), named: r.lf.WithField(lg.LoggerNameField, newName)}
 }
 
 func (r *logrSink) cloneValues() map[string]interface{} {
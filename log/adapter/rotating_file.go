@@ -0,0 +1,242 @@
+package adapter
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// backupTimeFormat is the timestamp appended to rotated files.
+const backupTimeFormat = "20060102T150405.000"
+
+// RotatingFileOptions configures a RotatingFile.
+type RotatingFileOptions struct {
+	// Filename is the active log file. Rotated files are written next to it
+	// as <name>-<timestamp><ext> (e.g. app-20240102T150405.000.log).
+	Filename string
+	// MaxSize rotates the file before a write would make it exceed this many
+	// bytes (0 disables size-based rotation).
+	MaxSize int64
+	// Interval rotates the file once it has been open for this long
+	// (0 disables time-based rotation).
+	Interval time.Duration
+	// MaxBackups is the number of rotated files kept (0 keeps all).
+	MaxBackups int
+	// MaxAge removes rotated files older than this (0 keeps all).
+	MaxAge time.Duration
+}
+
+// RotatingFile is an io.Writer that appends to a file and rotates it by size
+// and/or age, applying the retention limits after every rotation. It is safe
+// for concurrent use and can be combined with NewAsyncWriter or NewMultiLog.
+type RotatingFile struct {
+	opts RotatingFileOptions
+
+	mu       sync.Mutex
+	f        *os.File
+	size     int64
+	openedAt time.Time
+}
+
+// NewRotatingFile abre (ou cria) o arquivo de log informado em modo append,
+// criando o diretório quando necessário.
+func NewRotatingFile(opts RotatingFileOptions) (*RotatingFile, error) {
+	if opts.Filename == "" {
+		return nil, fmt.Errorf("log: rotating file requires a filename")
+	}
+	if err := os.MkdirAll(filepath.Dir(opts.Filename), 0o755); err != nil {
+		return nil, err
+	}
+	r := &RotatingFile{opts: opts}
+	if err := r.open(); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// Write appends p, rotating first when the size or age limit is reached.
+// When the rotation fails p is still appended to the current file and the
+// rotation error is returned; the rotation is retried on the next write.
+func (r *RotatingFile) Write(p []byte) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.f == nil {
+		return 0, os.ErrClosed
+	}
+	var rotateErr error
+	if r.shouldRotate(int64(len(p))) {
+		rotateErr = r.rotate()
+	}
+	n, err := r.f.Write(p)
+	r.size += int64(n)
+	if err != nil {
+		return n, err
+	}
+	return n, rotateErr
+}
+
+// Rotate forces a rotation (for example on SIGHUP).
+func (r *RotatingFile) Rotate() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.f == nil {
+		return os.ErrClosed
+	}
+	return r.rotate()
+}
+
+// Sync commits the active file to stable storage.
+func (r *RotatingFile) Sync() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.f == nil {
+		return nil
+	}
+	return r.f.Sync()
+}
+
+// Close closes the active file. Further writes return os.ErrClosed.
+func (r *RotatingFile) Close() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.f == nil {
+		return nil
+	}
+	err := r.f.Close()
+	r.f = nil
+	return err
+}
+
+func (r *RotatingFile) shouldRotate(n int64) bool {
+	if r.size == 0 {
+		return false
+	}
+	if r.opts.MaxSize > 0 && r.size+n > r.opts.MaxSize {
+		return true
+	}
+	return r.opts.Interval > 0 && time.Since(r.openedAt) >= r.opts.Interval
+}
+
+func (r *RotatingFile) open() error {
+	f, err := os.OpenFile(r.opts.Filename, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return err
+	}
+	info, err := f.Stat()
+	if err != nil {
+		_ = f.Close()
+		return err
+	}
+	r.f, r.size, r.openedAt = f, info.Size(), time.Now()
+	return nil
+}
+
+// rotate renames the active file to a timestamped backup, reopens the active
+// file and prunes old backups. The current file stays open until the new one
+// is ready, so a failed rotation never stops logging. Must be called with
+// r.mu held.
+func (r *RotatingFile) rotate() error {
+	old := r.f
+	if err := os.Rename(r.opts.Filename, r.backupName(time.Now())); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	if err := r.open(); err != nil {
+		return err
+	}
+	_ = old.Close()
+	r.prune()
+	return nil
+}
+
+// backupName returns an unused backup path for t.
+func (r *RotatingFile) backupName(t time.Time) string {
+	prefix, ext := r.backupParts()
+	name := prefix + t.Format(backupTimeFormat) + ext
+	for i := 1; ; i++ {
+		if _, err := os.Stat(name); os.IsNotExist(err) {
+			return name
+		}
+		name = fmt.Sprintf("%s%s.%d%s", prefix, t.Format(backupTimeFormat), i, ext)
+	}
+}
+
+func (r *RotatingFile) backupParts() (prefix, ext string) {
+	ext = filepath.Ext(r.opts.Filename)
+	return strings.TrimSuffix(r.opts.Filename, ext) + "-", ext
+}
+
+// prune applies MaxBackups and MaxAge. Errors are ignored: retention must
+// never prevent logging.
+func (r *RotatingFile) prune() {
+	if r.opts.MaxBackups <= 0 && r.opts.MaxAge <= 0 {
+		return
+	}
+	backups := r.backups()
+	// newest first: by timestamp, then by the collision suffix (.1, .2, ...)
+	sort.Slice(backups, func(i, j int) bool {
+		if !backups[i].at.Equal(backups[j].at) {
+			return backups[i].at.After(backups[j].at)
+		}
+		return backups[i].seq > backups[j].seq
+	})
+	cutoff := time.Now().Add(-r.opts.MaxAge)
+	for i, b := range backups {
+		remove := r.opts.MaxBackups > 0 && i >= r.opts.MaxBackups
+		if !remove && r.opts.MaxAge > 0 {
+			if info, err := os.Stat(b.path); err == nil && info.ModTime().Before(cutoff) {
+				remove = true
+			}
+		}
+		if remove {
+			_ = os.Remove(b.path)
+		}
+	}
+}
+
+// backup is a rotated file with the timestamp and collision suffix parsed
+// from its name (see backupName).
+type backup struct {
+	path string
+	at   time.Time
+	seq  int
+}
+
+// backups lists the rotated files of this RotatingFile.
+func (r *RotatingFile) backups() []backup {
+	prefix, ext := r.backupParts()
+	entries, err := os.ReadDir(filepath.Dir(r.opts.Filename))
+	if err != nil {
+		return nil
+	}
+	base := filepath.Base(prefix)
+	var out []backup
+	for _, e := range entries {
+		name := e.Name()
+		if e.IsDir() || !strings.HasPrefix(name, base) || !strings.HasSuffix(name, ext) {
+			continue
+		}
+		stamp := strings.TrimSuffix(strings.TrimPrefix(name, base), ext)
+		if len(stamp) < len(backupTimeFormat) {
+			continue
+		}
+		at, err := time.Parse(backupTimeFormat, stamp[:len(backupTimeFormat)])
+		if err != nil {
+			continue
+		}
+		seq := 0
+		if suffix := stamp[len(backupTimeFormat):]; suffix != "" {
+			n, err := strconv.Atoi(strings.TrimPrefix(suffix, "."))
+			if err != nil || suffix[0] != '.' {
+				continue
+			}
+			seq = n
+		}
+		out = append(out, backup{path: filepath.Join(filepath.Dir(r.opts.Filename), name), at: at, seq: seq})
+	}
+	return out
+}
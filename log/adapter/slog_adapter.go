@@ -0,0 +1,206 @@
+package adapter
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"log/slog"
+	"os"
+	"runtime"
+	"sort"
+	"time"
+
+	lg "github.com/totvs/go-sdk/log"
+	"github.com/totvs/go-sdk/trace"
+)
+
+// slogLogger is a LoggerFacade backed by an arbitrary slog.Handler, allowing
+// the SDK to be used without zerolog (for example with slog.NewJSONHandler or
+// a handler provided by another library).
+type slogLogger struct {
+	h     slog.Handler
+	level *lg.AtomicLevel
+	name  string
+}
+
+// NewSlogLogger cria uma LoggerFacade que encaminha os eventos para o
+// slog.Handler informado, filtrando pelo nível (ajustável em tempo de
+// execução via SetLevel). O handler aplica o seu próprio filtro em seguida.
+func NewSlogLogger(h slog.Handler, level lg.Level) lg.LoggerFacade {
+	return &slogLogger{h: h, level: lg.NewAtomicLevel(level)}
+}
+
+func (l *slogLogger) derive(h slog.Handler, name string) *slogLogger {
+	return &slogLogger{h: h, level: l.level, name: name}
+}
+
+func (l *slogLogger) WithField(k string, v interface{}) lg.LoggerFacade {
+	name := l.name
+	if s, ok := v.(string); ok && k == lg.LoggerNameField {
+		name = s
+	}
+	return l.derive(l.h.WithAttrs([]slog.Attr{slog.Any(k, v)}), name)
+}
+
+func (l *slogLogger) WithFields(fields map[string]interface{}) lg.LoggerFacade {
+	if len(fields) == 0 {
+		return l
+	}
+	name := l.name
+	attrs := make([]slog.Attr, 0, len(fields))
+	for k, v := range fields {
+		if s, ok := v.(string); ok && k == lg.LoggerNameField {
+			name = s
+		}
+		attrs = append(attrs, slog.Any(k, v))
+	}
+	return l.derive(l.h.WithAttrs(attrs), name)
+}
+
+func (l *slogLogger) WithTraceFromContext(ctx context.Context) lg.LoggerFacade {
+	tid := trace.TraceIDFromContext(ctx)
+	if tid == "" {
+		return l
+	}
+	attrs := []slog.Attr{slog.String(trace.TraceIDField, tid)}
+	if sid := trace.SpanIDFromContext(ctx); sid != "" {
+		attrs = append(attrs, slog.String(trace.SpanIDField, sid))
+	}
+	return l.derive(l.h.WithAttrs(attrs), l.name)
+}
+
+func (l *slogLogger) Enabled(level lg.Level) bool {
+	return l.level.Enabled(l.name, level) && l.h.Enabled(context.Background(), LevelToSlog(level))
+}
+
+func (l *slogLogger) event(level lg.Level, err error) lg.LogEvent {
+	if !l.Enabled(level) {
+		return &slogEvent{}
+	}
+	ev := &slogEvent{h: l.h, level: level}
+	return ev.Err(err)
+}
+
+func (l *slogLogger) Trace() lg.LogEvent          { return l.event(lg.TraceLevel, nil) }
+func (l *slogLogger) Debug() lg.LogEvent          { return l.event(lg.DebugLevel, nil) }
+func (l *slogLogger) Info() lg.LogEvent           { return l.event(lg.InfoLevel, nil) }
+func (l *slogLogger) Warn() lg.LogEvent           { return l.event(lg.WarnLevel, nil) }
+func (l *slogLogger) Error(err error) lg.LogEvent { return l.event(lg.ErrorLevel, err) }
+
+// Fatal and Panic are never filtered, mirroring the zerolog backend.
+func (l *slogLogger) Fatal(err error) lg.LogEvent {
+	return (&slogEvent{h: l.h, level: lg.FatalLevel}).Err(err)
+}
+
+func (l *slogLogger) Panic(err error) lg.LogEvent {
+	return (&slogEvent{h: l.h, level: lg.PanicLevel}).Err(err)
+}
+
+func (l *slogLogger) SetLevel(level lg.Level)      { l.level.SetLevel(level) }
+func (l *slogLogger) GetLevel() lg.Level           { return l.level.LevelFor(l.name) }
+func (l *slogLogger) AtomicLevel() *lg.AtomicLevel { return l.level }
+
+// slogEvent accumulates attributes until Msg builds and handles the record.
+// A nil handler marks a disabled event; sub marks the nested events created
+// by Dict, whose attributes become a group and which are never handled.
+type slogEvent struct {
+	h     slog.Handler
+	level lg.Level
+	attrs []slog.Attr
+	sub   bool
+}
+
+func (e *slogEvent) add(a slog.Attr) lg.LogEvent {
+	if e.h != nil {
+		e.attrs = append(e.attrs, a)
+	}
+	return e
+}
+
+func (e *slogEvent) Str(k, v string) lg.LogEvent           { return e.add(slog.String(k, v)) }
+func (e *slogEvent) Int(k string, v int) lg.LogEvent       { return e.add(slog.Int(k, v)) }
+func (e *slogEvent) Int64(k string, v int64) lg.LogEvent   { return e.add(slog.Int64(k, v)) }
+func (e *slogEvent) Uint(k string, v uint) lg.LogEvent     { return e.add(slog.Uint64(k, uint64(v))) }
+func (e *slogEvent) Uint64(k string, v uint64) lg.LogEvent { return e.add(slog.Uint64(k, v)) }
+func (e *slogEvent) Bool(k string, v bool) lg.LogEvent     { return e.add(slog.Bool(k, v)) }
+func (e *slogEvent) Float32(k string, v float32) lg.LogEvent {
+	return e.add(slog.Float64(k, float64(v)))
+}
+func (e *slogEvent) Float64(k string, v float64) lg.LogEvent { return e.add(slog.Float64(k, v)) }
+func (e *slogEvent) Interface(k string, v interface{}) lg.LogEvent {
+	return e.add(slog.Any(k, v))
+}
+func (e *slogEvent) Dur(k string, v time.Duration) lg.LogEvent { return e.add(slog.Duration(k, v)) }
+func (e *slogEvent) Time(k string, v time.Time) lg.LogEvent    { return e.add(slog.Time(k, v)) }
+func (e *slogEvent) Strs(k string, v []string) lg.LogEvent     { return e.add(slog.Any(k, v)) }
+func (e *slogEvent) Ints(k string, v []int) lg.LogEvent        { return e.add(slog.Any(k, v)) }
+func (e *slogEvent) Hex(k string, v []byte) lg.LogEvent {
+	return e.add(slog.String(k, hex.EncodeToString(v)))
+}
+func (e *slogEvent) Bytes(k string, v []byte) lg.LogEvent { return e.add(slog.String(k, string(v))) }
+func (e *slogEvent) Stringer(k string, v fmt.Stringer) lg.LogEvent {
+	if v == nil {
+		return e.add(slog.Any(k, nil))
+	}
+	return e.add(slog.String(k, v.String()))
+}
+func (e *slogEvent) Dict(k string, fn func(d lg.LogEvent)) lg.LogEvent {
+	if e.h == nil || fn == nil {
+		return e
+	}
+	d := &slogEvent{h: e.h, sub: true}
+	fn(d)
+	return e.add(slog.Attr{Key: k, Value: slog.GroupValue(d.attrs...)})
+}
+func (e *slogEvent) Fields(fields map[string]interface{}) lg.LogEvent {
+	keys := make([]string, 0, len(fields))
+	for k := range fields {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		e.add(slog.Any(k, fields[k]))
+	}
+	return e
+}
+func (e *slogEvent) Err(err error) lg.LogEvent {
+	if err == nil {
+		return e
+	}
+	return e.add(slog.Any("error", err))
+}
+
+func (e *slogEvent) Msg(msg string) { e.msg(msg, 3) }
+
+func (e *slogEvent) Msgf(format string, args ...interface{}) { e.msg(fmt.Sprintf(format, args...), 3) }
+
+func (e *slogEvent) Write(p []byte) (int, error) {
+	n := len(p)
+	if n > 0 && p[n-1] == '\n' {
+		// Trim CR added by stdlog.
+		p = p[:n-1]
+	}
+	e.msg(string(p), 3)
+	return n, nil
+}
+
+// msg handles the record and applies the Fatal/Panic side effects. skip is
+// the number of frames between the caller and runtime.Callers.
+func (e *slogEvent) msg(msg string, skip int) {
+	if e.sub {
+		return
+	}
+	if e.h != nil {
+		var pcs [1]uintptr
+		runtime.Callers(skip, pcs[:])
+		r := slog.NewRecord(time.Now(), LevelToSlog(e.level), msg, pcs[0])
+		r.AddAttrs(e.attrs...)
+		_ = e.h.Handle(context.Background(), r)
+	}
+	switch e.level {
+	case lg.FatalLevel:
+		os.Exit(1)
+	case lg.PanicLevel:
+		panic(msg)
+	}
+}
@@ -0,0 +1,205 @@
+package adapter
+
+import (
+	"context"
+	"log/slog"
+
+	lg "github.com/totvs/go-sdk/log"
+)
+
+// slogHandler implements slog.Handler and forwards records to a
+// LoggerFacade. Attributes added via WithAttrs and groups opened via
+// WithGroup are kept as a tree of maps so groups render as nested objects.
+type slogHandler struct {
+	lf     lg.LoggerFacade
+	fields map[string]interface{}
+	groups []string
+}
+
+// NewSlogHandler cria um slog.Handler que encaminha os registros para a
+// LoggerFacade fornecida (atributos, grupos e níveis).
+func NewSlogHandler(l lg.LoggerFacade) slog.Handler {
+	return &slogHandler{lf: l}
+}
+
+// InstallSlogDefault registra a LoggerFacade como logger padrão de `log/slog`
+// (slog.SetDefault), de modo que bibliotecas que usam slog.Info/... acabem na
+// fachada.
+func InstallSlogDefault(l lg.LoggerFacade) { slog.SetDefault(slog.New(NewSlogHandler(l))) }
+
+// SlogLevelToLevel maps a slog level to a facade level. Levels between the
+// slog constants round down (e.g. slog.LevelInfo+2 is Info), and levels below
+// slog.LevelDebug are Trace.
+func SlogLevelToLevel(l slog.Level) lg.Level {
+	switch {
+	case l < slog.LevelDebug:
+		return lg.TraceLevel
+	case l < slog.LevelInfo:
+		return lg.DebugLevel
+	case l < slog.LevelWarn:
+		return lg.InfoLevel
+	case l < slog.LevelError:
+		return lg.WarnLevel
+	default:
+		return lg.ErrorLevel
+	}
+}
+
+// LevelToSlog maps a facade level to a slog level. Trace, Fatal and Panic have
+// no slog constant and are mapped four steps away from Debug and Error.
+func LevelToSlog(l lg.Level) slog.Level {
+	switch l {
+	case lg.TraceLevel:
+		return slog.LevelDebug - 4
+	case lg.DebugLevel:
+		return slog.LevelDebug
+	case lg.InfoLevel:
+		return slog.LevelInfo
+	case lg.WarnLevel:
+		return slog.LevelWarn
+	case lg.ErrorLevel:
+		return slog.LevelError
+	case lg.FatalLevel:
+		return slog.LevelError + 4
+	default:
+		return slog.LevelError + 8
+	}
+}
+
+func (h *slogHandler) Enabled(_ context.Context, level slog.Level) bool {
+	return h.lf.Enabled(SlogLevelToLevel(level))
+}
+
+func (h *slogHandler) Handle(ctx context.Context, r slog.Record) error {
+	fields := h.fields
+	var err error
+	if r.NumAttrs() > 0 {
+		fields = cloneTree(h.fields)
+		dest := groupMap(fields, h.groups)
+		r.Attrs(func(a slog.Attr) bool {
+			// the first error attribute of an error record becomes the event error.
+			if e, ok := a.Value.Resolve().Any().(error); ok && err == nil && r.Level >= slog.LevelError {
+				err = e
+				return true
+			}
+			addAttr(dest, a)
+			return true
+		})
+	}
+
+	lf := lg.WithContext(h.lf, ctx)
+	if len(fields) > 0 {
+		lf = lf.WithFields(fields)
+	}
+	var ev lg.LogEvent
+	switch SlogLevelToLevel(r.Level) {
+	case lg.TraceLevel:
+		ev = lf.Trace()
+	case lg.DebugLevel:
+		ev = lf.Debug()
+	case lg.InfoLevel:
+		ev = lf.Info()
+	case lg.WarnLevel:
+		ev = lf.Warn()
+	default:
+		ev = lf.Error(err)
+	}
+	ev.Msg(r.Message)
+	return nil
+}
+
+func (h *slogHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	if len(attrs) == 0 {
+		return h
+	}
+	fields := cloneTree(h.fields)
+	dest := groupMap(fields, h.groups)
+	for _, a := range attrs {
+		addAttr(dest, a)
+	}
+	return &slogHandler{lf: h.lf, fields: fields, groups: h.groups}
+}
+
+func (h *slogHandler) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return h
+	}
+	groups := make([]string, len(h.groups), len(h.groups)+1)
+	copy(groups, h.groups)
+	return &slogHandler{lf: h.lf, fields: h.fields, groups: append(groups, name)}
+}
+
+// groupMap returns the map for the given group path inside root, creating
+// intermediate maps as needed.
+func groupMap(root map[string]interface{}, groups []string) map[string]interface{} {
+	m := root
+	for _, g := range groups {
+		next, ok := m[g].(map[string]interface{})
+		if !ok {
+			next = map[string]interface{}{}
+			m[g] = next
+		}
+		m = next
+	}
+	return m
+}
+
+// addAttr converts a slog attribute into a plain value stored in dest.
+func addAttr(dest map[string]interface{}, a slog.Attr) {
+	v := a.Value.Resolve()
+	if a.Key == "" && v.Kind() != slog.KindGroup {
+		return // slog drops attributes with empty keys
+	}
+	if v.Kind() == slog.KindGroup {
+		attrs := v.Group()
+		if len(attrs) == 0 {
+			return
+		}
+		target := dest
+		if a.Key != "" {
+			target = groupMap(dest, []string{a.Key})
+		}
+		for _, ga := range attrs {
+			addAttr(target, ga)
+		}
+		return
+	}
+	dest[a.Key] = slogValue(v)
+}
+
+func slogValue(v slog.Value) interface{} {
+	switch v.Kind() {
+	case slog.KindString:
+		return v.String()
+	case slog.KindInt64:
+		return v.Int64()
+	case slog.KindUint64:
+		return v.Uint64()
+	case slog.KindFloat64:
+		return v.Float64()
+	case slog.KindBool:
+		return v.Bool()
+	case slog.KindDuration:
+		return v.Duration().String()
+	case slog.KindTime:
+		return v.Time()
+	default:
+		if err, ok := v.Any().(error); ok {
+			return err.Error()
+		}
+		return v.Any()
+	}
+}
+
+// cloneTree deep-copies the nested maps created for groups; leaf values are shared.
+func cloneTree(m map[string]interface{}) map[string]interface{} {
+	out := make(map[string]interface{}, len(m))
+	for k, v := range m {
+		if sub, ok := v.(map[string]interface{}); ok {
+			out[k] = cloneTree(sub)
+			continue
+		}
+		out[k] = v
+	}
+	return out
+}
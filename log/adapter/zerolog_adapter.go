@@ -7,8 +7,80 @@ import (
 	backend "github.com/totvs/go-sdk/log/internal/backend"
 )
 
+// Option customizes the loggers created by NewLog/NewDefaultLog.
+type Option = backend.Option
+
+// SamplingConfig configures sampling and burst limiting (see WithSampling).
+type SamplingConfig = backend.SamplingConfig
+
+// DroppedEventsMetric is the counter incremented for events dropped by sampling.
+const DroppedEventsMetric = backend.DroppedEventsMetric
+
+// RedactionConfig configures sensitive-value masking (see WithRedaction).
+type RedactionConfig = backend.RedactionConfig
+
+// Detector finds sensitive data inside string values.
+type Detector = backend.Detector
+
+// Built-in detectors for JWTs, bearer tokens, e-mails and Brazilian document
+// numbers (CPF/CNPJ, validated by their check digits).
+var (
+	DetectBearer = backend.DetectBearer
+	DetectJWT    = backend.DetectJWT
+	DetectEmail  = backend.DetectEmail
+	DetectCPF    = backend.DetectCPF
+	DetectCNPJ   = backend.DetectCNPJ
+)
+
+// Sink is one destination of NewMultiLog, with its own level and format.
+type Sink = backend.Sink
+
+// DefaultRedactionConfig returns the recommended redaction configuration.
+func DefaultRedactionConfig() RedactionConfig { return backend.DefaultRedactionConfig() }
+
 // NewLog delegates to the internal zerolog backend.
-func NewLog(w io.Writer, level lg.Level) lg.LoggerFacade { return backend.NewLog(w, level) }
+func NewLog(w io.Writer, level lg.Level, opts ...Option) lg.LoggerFacade {
+	return backend.NewLog(w, level, opts...)
+}
+
+// NewMultiLog delegates to the internal zerolog backend. Each event is
+// written to every sink whose level accepts it.
+func NewMultiLog(sinks []Sink, opts ...Option) lg.LoggerFacade {
+	return backend.NewMultiLog(sinks, opts...)
+}
+
+// NewDefaultLog delegates to the internal zerolog backend. The output format
+// can be selected via the LOG_FORMAT environment variable.
+func NewDefaultLog(opts ...Option) lg.LoggerFacade { return backend.NewDefaultLog(opts...) }
+
+// WithFormat selects the output format (json, console, logfmt, ecs, otel).
+func WithFormat(f lg.Format) Option { return backend.WithFormat(f) }
+
+// WithNoColor disables ANSI colors in the console format.
+func WithNoColor() Option { return backend.WithNoColor() }
+
+// WithFieldNames renames top-level output fields (for example
+// `{"message": "msg"}`). Ignored by the console format.
+func WithFieldNames(names map[string]string) Option { return backend.WithFieldNames(names) }
+
+// WithAtomicLevel shares a runtime-adjustable level holder with the logger.
+// The level passed to the constructor is ignored when a holder is supplied.
+func WithAtomicLevel(level *lg.AtomicLevel) Option { return backend.WithAtomicLevel(level) }
+
+// WithSampling enables event sampling and burst limiting. Dropped events are
+// counted in DroppedEventsMetric through the metrics facade.
+func WithSampling(cfg SamplingConfig) Option { return backend.WithSampling(cfg) }
+
+// WithRedaction masks sensitive field names and values before they are
+// written. Use DefaultRedactionConfig() as a starting point.
+func WithRedaction(cfg RedactionConfig) Option { return backend.WithRedaction(cfg) }
+
+// WithCaller adds the caller file:line to every event.
+func WithCaller() Option { return backend.WithCaller() }
+
+// WithStackTrace adds the call-site stack trace to Error, Fatal and Panic events.
+func WithStackTrace() Option { return backend.WithStackTrace() }
 
-// NewDefaultLog delegates to the internal zerolog backend.
-func NewDefaultLog() lg.LoggerFacade { return backend.NewDefaultLog() }
+// WithErrorChain renders wrapped and joined errors (with their types and
+// log.ErrorFielder fields) as nested objects under log.ErrorChainField.
+func WithErrorChain() Option { return backend.WithErrorChain() }
@@ -0,0 +1,241 @@
+package log_test
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	logger "github.com/totvs/go-sdk/log"
+	adapter "github.com/totvs/go-sdk/log/adapter"
+	mt "github.com/totvs/go-sdk/metrics"
+)
+
+// countingMetrics counts the increments of the counter called name and keeps
+// the attributes of the last one; other instruments come from the embedded
+// facade.
+type countingMetrics struct {
+	mt.MetricsFacade
+	name string
+	n    atomic.Int64
+
+	mu    sync.Mutex
+	attrs []mt.Attribute
+}
+
+// lastAttr returns the value of the attribute key of the last increment.
+func (c *countingMetrics) lastAttr(key string) (interface{}, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, a := range c.attrs {
+		if a.Key == key {
+			return a.Value, true
+		}
+	}
+	return nil, false
+}
+
+func (c *countingMetrics) GetOrCreateCounter(name string, typ mt.MetricType, class mt.MetricClass, _ ...mt.MetricOption) mt.Counter {
+	if name != c.name {
+		return c.MetricsFacade.GetOrCreateCounter(name, typ, class)
+	}
+	return countingCounter{c}
+}
+
+type countingCounter struct{ c *countingMetrics }
+
+func (c countingCounter) Add(_ context.Context, incr int64, attrs ...mt.Attribute) {
+	c.c.n.Add(incr)
+	c.c.mu.Lock()
+	c.c.attrs = attrs
+	c.c.mu.Unlock()
+}
+func (c countingCounter) Inc(ctx context.Context, attrs ...mt.Attribute) { c.Add(ctx, 1, attrs...) }
+
+// gatedWriter blocks every Write until release is closed and reports each
+// write on started, so tests can pin the background goroutine on a line.
+type gatedWriter struct {
+	mu      sync.Mutex
+	buf     bytes.Buffer
+	started chan struct{}
+	release chan struct{}
+	syncs   atomic.Int32
+}
+
+func newGatedWriter() *gatedWriter {
+	return &gatedWriter{started: make(chan struct{}, 100), release: make(chan struct{})}
+}
+
+func (g *gatedWriter) Write(p []byte) (int, error) {
+	g.started <- struct{}{}
+	<-g.release
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return g.buf.Write(p)
+}
+
+func (g *gatedWriter) Sync() error {
+	g.syncs.Add(1)
+	return nil
+}
+
+func (g *gatedWriter) String() string {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return g.buf.String()
+}
+
+// fillPinned writes "1" and waits until the writer goroutine is blocked on it,
+// then writes the remaining lines into the buffer.
+func fillPinned(t *testing.T, aw *adapter.AsyncWriter, g *gatedWriter, lines ...string) {
+	t.Helper()
+	_, _ = aw.Write([]byte("1\n"))
+	select {
+	case <-g.started:
+	case <-time.After(time.Second):
+		t.Fatal("writer goroutine did not pick up the first line")
+	}
+	for _, l := range lines {
+		_, _ = aw.Write([]byte(l + "\n"))
+	}
+}
+
+func TestAsyncWriterDropPolicies(t *testing.T) {
+	cases := []struct {
+		policy adapter.DropPolicy
+		want   string
+	}{
+		{adapter.DropNewest, "1\n2\n3\n"},
+		{adapter.DropOldest, "1\n4\n5\n"},
+	}
+	for _, tc := range cases {
+		g := newGatedWriter()
+		m := &countingMetrics{MetricsFacade: mt.GetGlobal(), name: adapter.AsyncDroppedLinesMetric}
+		aw := adapter.NewAsyncWriter(g, adapter.AsyncWriterOptions{BufferSize: 2, Policy: tc.policy, FlushInterval: -1, Metrics: m})
+
+		fillPinned(t, aw, g, "2", "3", "4", "5")
+		if aw.Dropped() != 2 {
+			t.Fatalf("policy %d: expected 2 dropped lines, got %d", tc.policy, aw.Dropped())
+		}
+		if got := m.n.Load(); got != 2 {
+			t.Fatalf("policy %d: expected dropped counter 2, got %v", tc.policy, got)
+		}
+		close(g.release)
+		if err := aw.Close(); err != nil {
+			t.Fatalf("close: %v", err)
+		}
+		if got := g.String(); got != tc.want {
+			t.Fatalf("policy %d: expected %q, got %q", tc.policy, tc.want, got)
+		}
+	}
+}
+
+func TestAsyncWriterBlockPolicy(t *testing.T) {
+	g := newGatedWriter()
+	aw := adapter.NewAsyncWriter(g, adapter.AsyncWriterOptions{BufferSize: 1, Policy: adapter.Block, FlushInterval: -1})
+	fillPinned(t, aw, g, "2")
+
+	done := make(chan struct{})
+	go func() {
+		_, _ = aw.Write([]byte("3\n"))
+		close(done)
+	}()
+	select {
+	case <-done:
+		t.Fatal("write should block while the buffer is full")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	close(g.release)
+	<-done
+	if err := aw.Flush(); err != nil {
+		t.Fatalf("flush: %v", err)
+	}
+	if got := g.String(); got != "1\n2\n3\n" || aw.Dropped() != 0 {
+		t.Fatalf("expected every line written, got %q (dropped %d)", got, aw.Dropped())
+	}
+	_ = aw.Close()
+}
+
+func TestAsyncWriterWithLogger(t *testing.T) {
+	buf := &syncBuffer{}
+	aw := adapter.NewAsyncWriter(buf, adapter.AsyncWriterOptions{})
+	l := adapter.NewLog(aw, logger.InfoLevel)
+	for i := 0; i < 100; i++ {
+		l.Info().Int("i", i).Msg("async")
+	}
+	if err := aw.Close(); err != nil {
+		t.Fatalf("close: %v", err)
+	}
+	if n := strings.Count(buf.String(), `"message":"async"`); n != 100 {
+		t.Fatalf("expected 100 lines after Close, got %d", n)
+	}
+	if _, err := aw.Write([]byte("late\n")); !errors.Is(err, adapter.ErrAsyncWriterClosed) {
+		t.Fatalf("expected ErrAsyncWriterClosed after Close, got %v", err)
+	}
+}
+
+func TestAsyncWriterPeriodicFlush(t *testing.T) {
+	g := newGatedWriter()
+	close(g.release)
+	aw := adapter.NewAsyncWriter(g, adapter.AsyncWriterOptions{FlushInterval: 5 * time.Millisecond})
+	defer aw.Close()
+
+	deadline := time.Now().Add(time.Second)
+	for g.syncs.Load() < 2 {
+		if time.Now().After(deadline) {
+			t.Fatal("expected the destination to be synced periodically")
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+func TestAsyncWriterFlushesTakeTurnsWithWrites(t *testing.T) {
+	dst := &syncBuffer{}
+	bw := bufio.NewWriterSize(dst, 64) // not safe for concurrent use
+	aw := adapter.NewAsyncWriter(bw, adapter.AsyncWriterOptions{FlushInterval: time.Millisecond, Policy: adapter.Block})
+
+	const lines = 2000
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < lines/4; j++ {
+				_, _ = aw.Write([]byte("0123456789\n"))
+				if j%100 == 0 {
+					_ = aw.Flush()
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	if err := aw.Close(); err != nil {
+		t.Fatalf("close: %v", err)
+	}
+	if got := strings.Count(dst.String(), "0123456789\n"); got != lines {
+		t.Fatalf("expected %d intact lines, got %d", lines, got)
+	}
+}
+
+type syncBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (s *syncBuffer) Write(p []byte) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.buf.Write(p)
+}
+
+func (s *syncBuffer) String() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.buf.String()
+}
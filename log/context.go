@@ -0,0 +1,198 @@
+package log
+
+import (
+	"context"
+
+	"github.com/totvs/go-sdk/internal/ctxfields"
+	"github.com/totvs/go-sdk/trace"
+)
+
+// Field names added by the built-in context extractors.
+const (
+	TenantIDField  = "tenant_id"
+	UserIDField    = "user_id"
+	RequestIDField = "request_id"
+)
+
+// Names of the built-in context extractors (see RegisterContextExtractor).
+const (
+	TraceExtractor     = "trace"
+	TenantExtractor    = "tenant"
+	UserExtractor      = "user"
+	RequestIDExtractor = "request_id"
+	FieldsExtractor    = "fields"
+)
+
+const (
+	userKey        ctxKey = "user-id"
+	requestIDKey   ctxKey = "request-id"
+	fieldsKey      ctxKey = "fields"
+	boundFieldsKey ctxKey = "bound-fields"
+)
+
+// ContextExtractor reads values from ctx and reports them as log fields
+// through set. Extractors must be cheap and safe for concurrent use.
+type ContextExtractor func(ctx context.Context, set func(k string, v interface{}))
+
+func init() {
+	for _, k := range []ctxKey{loggerKey, boundFieldsKey, userKey, requestIDKey, fieldsKey} {
+		trace.RegisterDetachKey(k)
+	}
+}
+
+// extractors is the registry applied by ContextFields, starting with the
+// built-in extractors.
+var extractors = ctxfields.NewRegistry(
+	ctxfields.Named{Name: TraceExtractor, Fn: func(ctx context.Context, set func(string, interface{})) {
+		if tid := trace.TraceIDFromContext(ctx); tid != "" {
+			set(trace.TraceIDField, tid)
+		}
+		if sid := trace.SpanIDFromContext(ctx); sid != "" {
+			set(trace.SpanIDField, sid)
+		}
+		if oid := trace.OTelTraceIDFromContext(ctx); oid != "" {
+			set(trace.OTelTraceIDField, oid)
+		}
+		if pid := trace.ParentSpanIDFromContext(ctx); pid != "" {
+			set(trace.ParentSpanIDField, pid)
+		}
+	}},
+	ctxfields.Named{Name: TenantExtractor, Fn: ctxfields.TenantID(TenantIDField)},
+	ctxfields.Named{Name: UserExtractor, Fn: ctxfields.StringValue(userKey, UserIDField)},
+	ctxfields.Named{Name: RequestIDExtractor, Fn: ctxfields.StringValue(requestIDKey, RequestIDField)},
+	ctxfields.Named{Name: FieldsExtractor, Fn: func(ctx context.Context, set func(string, interface{})) {
+		if m, ok := ctx.Value(fieldsKey).(map[string]interface{}); ok {
+			for k, v := range m {
+				set(k, v)
+			}
+		}
+	}},
+)
+
+// RegisterContextExtractor adds (or replaces, when name is already
+// registered) an extractor applied by Ctx and WithContext. Extractors run in
+// registration order; a later extractor overrides fields set by earlier ones.
+// A nil fn removes the extractor.
+func RegisterContextExtractor(name string, fn ContextExtractor) {
+	extractors.Register(name, ctxfields.Extractor(fn))
+}
+
+// UnregisterContextExtractor removes the extractor registered as name.
+func UnregisterContextExtractor(name string) { RegisterContextExtractor(name, nil) }
+
+// RegisterContextKey registers an extractor (named field) that logs the value
+// stored in the context under key as field, when present and not nil. The
+// value is also kept by trace.Detach.
+func RegisterContextKey(field string, key interface{}) {
+	trace.RegisterDetachKey(key)
+	RegisterContextExtractor(field, func(ctx context.Context, set func(string, interface{})) {
+		if v := ctx.Value(key); v != nil {
+			set(field, v)
+		}
+	})
+}
+
+// ContextFields runs the registered extractors on ctx and returns the
+// collected fields (nil when there are none).
+func ContextFields(ctx context.Context) map[string]interface{} {
+	if ctx == nil {
+		return nil
+	}
+	var fields map[string]interface{}
+	set := func(k string, v interface{}) {
+		if fields == nil {
+			fields = make(map[string]interface{}, 4)
+		}
+		fields[k] = v
+	}
+	extractors.Run(ctx, set)
+	return fields
+}
+
+// WithContext returns l enriched with the fields extracted from ctx.
+func WithContext(l LoggerFacade, ctx context.Context) LoggerFacade {
+	if fields := ContextFields(ctx); len(fields) > 0 {
+		return l.WithFields(fields)
+	}
+	return l
+}
+
+// Ctx returns the logger stored in ctx (or the global logger) enriched with
+// the fields extracted from ctx. Fields the stored logger already carries
+// (see ContextWithBoundLogger) are not repeated: on a key collision the bound
+// value wins, even when the context now holds a different one.
+func Ctx(ctx context.Context) LoggerFacade {
+	l := FromContext(ctx)
+	fields := ContextFields(ctx)
+	if len(fields) == 0 {
+		return l
+	}
+	if bound, ok := ctx.Value(boundFieldsKey).(map[string]interface{}); ok {
+		for k := range fields {
+			if _, ok := bound[k]; ok {
+				delete(fields, k)
+			}
+		}
+		if len(fields) == 0 {
+			return l
+		}
+	}
+	return l.WithFields(fields)
+}
+
+// ContextWithBoundLogger armazena l no contexto, como ContextWithLogger, e
+// registra que l já carrega os campos extraídos de ctx (por exemplo, criado
+// com WithContext). Assim Ctx só acrescenta campos adicionados ao contexto
+// depois desta chamada.
+func ContextWithBoundLogger(ctx context.Context, l LoggerFacade) context.Context {
+	ctx = ContextWithLogger(ctx, l)
+	return context.WithValue(ctx, boundFieldsKey, ContextFields(ctx))
+}
+
+// ContextWithTenantID returns a context carrying the tenant id logged as
+// tenant_id. The same value is reported by metrics.ContextAttributes.
+func ContextWithTenantID(ctx context.Context, id string) context.Context {
+	return ctxfields.ContextWithTenantID(ctx, id)
+}
+
+// TenantIDFromContext returns the tenant id stored in ctx (by this package or
+// by metrics.ContextWithTenantID), if any.
+func TenantIDFromContext(ctx context.Context) string { return ctxfields.TenantIDFromContext(ctx) }
+
+// ContextWithUserID returns a context carrying the user id logged as user_id.
+func ContextWithUserID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, userKey, id)
+}
+
+// UserIDFromContext returns the user id stored in ctx, if any.
+func UserIDFromContext(ctx context.Context) string { return stringValue(ctx, userKey) }
+
+// ContextWithRequestID returns a context carrying the request id logged as request_id.
+func ContextWithRequestID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, requestIDKey, id)
+}
+
+// RequestIDFromContext returns the request id stored in ctx, if any.
+func RequestIDFromContext(ctx context.Context) string { return stringValue(ctx, requestIDKey) }
+
+// ContextWithFields returns a context carrying extra log fields, merged over
+// the fields already stored in ctx.
+func ContextWithFields(ctx context.Context, fields map[string]interface{}) context.Context {
+	prev, _ := ctx.Value(fieldsKey).(map[string]interface{})
+	merged := make(map[string]interface{}, len(prev)+len(fields))
+	for k, v := range prev {
+		merged[k] = v
+	}
+	for k, v := range fields {
+		merged[k] = v
+	}
+	return context.WithValue(ctx, fieldsKey, merged)
+}
+
+func stringValue(ctx context.Context, key ctxKey) string {
+	if ctx == nil {
+		return ""
+	}
+	s, _ := ctx.Value(key).(string)
+	return s
+}
@@ -0,0 +1,79 @@
+package log_test
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	logger "github.com/totvs/go-sdk/log"
+	adapter "github.com/totvs/go-sdk/log/adapter"
+	"github.com/totvs/go-sdk/log/logtest"
+	middleware "github.com/totvs/go-sdk/log/middleware"
+	"github.com/totvs/go-sdk/trace"
+)
+
+type orderKey struct{}
+
+func TestCtxAppliesBuiltinExtractors(t *testing.T) {
+	rec := logtest.New()
+	ctx := logger.ContextWithLogger(context.Background(), rec)
+	ctx = trace.ContextWithTrace(ctx, "tid-1")
+	ctx = logger.ContextWithTenantID(ctx, "acme")
+	ctx = logger.ContextWithUserID(ctx, "u-7")
+	ctx = logger.ContextWithRequestID(ctx, "req-3")
+	ctx = logger.ContextWithFields(ctx, map[string]interface{}{"region": "br"})
+	ctx = logger.ContextWithFields(ctx, map[string]interface{}{"zone": "a"})
+
+	logger.Ctx(ctx).Info().Msg("hello")
+
+	logtest.AssertLogged(t, rec, logtest.Message("hello"),
+		logtest.Field(trace.TraceIDField, "tid-1"), logtest.Field(logger.TenantIDField, "acme"),
+		logtest.Field(logger.UserIDField, "u-7"), logtest.Field(logger.RequestIDField, "req-3"),
+		logtest.Field("region", "br"), logtest.Field("zone", "a"))
+}
+
+func TestRegisterContextKeyAndExtractor(t *testing.T) {
+	logger.RestoreContextExtractors(t)
+	logger.RegisterContextKey("order_id", orderKey{})
+	logger.RegisterContextExtractor("upper", func(ctx context.Context, set func(string, interface{})) {
+		if s := logger.TenantIDFromContext(ctx); s != "" {
+			set("tenant_upper", strings.ToUpper(s))
+		}
+	})
+
+	ctx := context.WithValue(context.Background(), orderKey{}, 42)
+	ctx = logger.ContextWithTenantID(ctx, "acme")
+	fields := logger.ContextFields(ctx)
+	if fields["order_id"] != 42 || fields["tenant_upper"] != "ACME" {
+		t.Fatalf("unexpected context fields: %v", fields)
+	}
+
+	logger.UnregisterContextExtractor(logger.TenantExtractor)
+	if _, ok := logger.ContextFields(ctx)[logger.TenantIDField]; ok {
+		t.Fatal("expected the unregistered extractor not to run")
+	}
+}
+
+func TestCtxDoesNotRepeatBoundFields(t *testing.T) {
+	buf := &bytes.Buffer{}
+	var line string
+	h := middleware.HTTPMiddlewareWithOptions(adapter.NewLog(buf, logger.InfoLevel), middleware.MiddlewareOptions{InjectLogger: true})(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx := logger.ContextWithTenantID(r.Context(), "acme")
+			// A different trace id in the context must not repeat the bound key.
+			ctx = trace.ContextWithTrace(ctx, "tid-other")
+			logger.Ctx(ctx).Info().Msg("handled")
+			line = buf.String()
+		}))
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set(trace.TraceIDHTTPHeader, "tid-9")
+	h.ServeHTTP(httptest.NewRecorder(), req)
+
+	if strings.Count(line, `"trace_id"`) != 1 || !strings.Contains(line, `"trace_id":"tid-9"`) ||
+		!strings.Contains(line, `"tenant_id":"acme"`) {
+		t.Fatalf("expected the bound trace_id once and the new tenant field, got: %s", line)
+	}
+}
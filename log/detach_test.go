@@ -0,0 +1,25 @@
+package log_test
+
+import (
+	"context"
+	"testing"
+
+	logger "github.com/totvs/go-sdk/log"
+	"github.com/totvs/go-sdk/log/logtest"
+	"github.com/totvs/go-sdk/trace"
+)
+
+func TestDetachKeepsLoggerAndContextFields(t *testing.T) {
+	rec := logtest.New()
+	parent, cancel := context.WithCancel(context.Background())
+	ctx := logger.ContextWithLogger(trace.ContextWithTrace(parent, "tid-5"), rec)
+	ctx = logger.ContextWithTenantID(ctx, "acme")
+	ctx = context.WithValue(ctx, orderKey{}, 7)
+	logger.RestoreContextExtractors(t)
+	logger.RegisterContextKey("order_id", orderKey{})
+	cancel()
+
+	logger.Ctx(trace.Detach(ctx)).Info().Msg("background")
+	logtest.AssertLogged(t, rec, logtest.Message("background"), logtest.Field(trace.TraceIDField, "tid-5"),
+		logtest.Field(logger.TenantIDField, "acme"), logtest.Field("order_id", 7))
+}
@@ -0,0 +1,65 @@
+package log_test
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/totvs/go-sdk/auth/oauth2"
+	logger "github.com/totvs/go-sdk/log"
+	adapter "github.com/totvs/go-sdk/log/adapter"
+)
+
+func TestErrorChainRendersWrappedAndJoinedErrors(t *testing.T) {
+	buf := &bytes.Buffer{}
+	l := adapter.NewLog(buf, logger.InfoLevel, adapter.WithErrorChain())
+
+	endpoint := &oauth2.EndpointError{StatusCode: 400, Code: "invalid_grant"}
+	err := fmt.Errorf("refresh session: %w", errors.Join(endpoint, errors.New("cache miss")))
+	l.Error(err).Msg("refresh failed")
+
+	m := decodeLine(t, buf.Bytes())
+	if m["error"] != err.Error() {
+		t.Fatalf("expected the error message to be kept, got %v", m["error"])
+	}
+	chain, ok := m[logger.ErrorChainField].(map[string]interface{})
+	if !ok || chain["type"] != "*fmt.wrapError" {
+		t.Fatalf("expected a nested error chain, got %v", m[logger.ErrorChainField])
+	}
+	causes := chain["cause"].(map[string]interface{})["causes"].([]interface{})
+	if len(causes) != 2 {
+		t.Fatalf("expected both joined errors, got %v", causes)
+	}
+	first := causes[0].(map[string]interface{})
+	fields, _ := first["fields"].(map[string]interface{})
+	if first["type"] != "*oauth2.EndpointError" || fields["status_code"] != float64(400) || fields["code"] != "invalid_grant" {
+		t.Fatalf("expected EndpointError fields in the chain, got %v", first)
+	}
+}
+
+func TestCallerAndStackTrace(t *testing.T) {
+	buf := &bytes.Buffer{}
+	l := adapter.NewLog(buf, logger.InfoLevel, adapter.WithCaller(), adapter.WithStackTrace())
+
+	l.Info().Msg("with caller")
+	m := decodeLine(t, buf.Bytes())
+	if c, _ := m["caller"].(string); !strings.Contains(c, "error_chain_test.go:") {
+		t.Fatalf("expected the caller to point to the test file, got %v", m["caller"])
+	}
+	if _, ok := m["stack"]; ok {
+		t.Fatalf("info events must not carry a stack trace: %v", m)
+	}
+
+	buf.Reset()
+	l.WithField("k", "v").Error(errors.New("boom")).Msg("with stack")
+	m = decodeLine(t, buf.Bytes())
+	stack, _ := m["stack"].(string)
+	if !strings.HasPrefix(stack, "github.com/totvs/go-sdk/log_test.TestCallerAndStackTrace") {
+		t.Fatalf("expected the stack to start at the test function, got %q", stack)
+	}
+	if c, _ := m["caller"].(string); !strings.Contains(c, "error_chain_test.go:") {
+		t.Fatalf("expected the caller on derived loggers too, got %v", m["caller"])
+	}
+}
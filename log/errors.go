@@ -0,0 +1,12 @@
+package log
+
+// ErrorChainField is the field that carries the structured error chain when
+// the backend renders wrapped errors (see the backend WithErrorChain option).
+const ErrorChainField = "error_chain"
+
+// ErrorFielder is implemented by errors that expose structured, non-sensitive
+// fields (status codes, error codes, ...). Backends rendering the error chain
+// add these fields to the node of the error that implements it.
+type ErrorFielder interface {
+	ErrorFields() map[string]interface{}
+}
@@ -0,0 +1,83 @@
+package log_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"net"
+	"testing"
+	"time"
+
+	logger "github.com/totvs/go-sdk/log"
+	adapter "github.com/totvs/go-sdk/log/adapter"
+)
+
+// logRichEvent writes an event using every typed field helper.
+func logRichEvent(l logger.LoggerFacade) {
+	l.Info().
+		Dur("elapsed", 1500*time.Millisecond).
+		Time("at", time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)).
+		Strs("tags", []string{"a", "b"}).
+		Ints("codes", []int{200, 404}).
+		Stringer("ip", net.IPv4(10, 0, 0, 1)).
+		Hex("digest", []byte{0xde, 0xad}).
+		Bytes("raw", []byte("payload")).
+		Dict("http", func(d logger.LogEvent) {
+			d.Str("method", "GET").Int("status", 200)
+			d.Msg("ignored")
+		}).
+		Fields(map[string]interface{}{"tenant": "t1", "retries": 2}).
+		Msg("rich")
+}
+
+func TestRichEventFields(t *testing.T) {
+	zbuf, sbuf := &bytes.Buffer{}, &bytes.Buffer{}
+	loggers := map[string]logger.LoggerFacade{
+		"zerolog": adapter.NewLog(zbuf, logger.InfoLevel),
+		"slog":    adapter.NewSlogLogger(slog.NewJSONHandler(sbuf, nil), logger.InfoLevel),
+	}
+	bufs := map[string]*bytes.Buffer{"zerolog": zbuf, "slog": sbuf}
+
+	for name, l := range loggers {
+		logRichEvent(l)
+		var m map[string]interface{}
+		if err := json.Unmarshal(bufs[name].Bytes(), &m); err != nil {
+			t.Fatalf("%s: expected a single JSON line: %v, raw: %s", name, err, bufs[name].String())
+		}
+		httpObj, _ := m["http"].(map[string]interface{})
+		if httpObj["method"] != "GET" || httpObj["status"] != float64(200) {
+			t.Fatalf("%s: expected nested dict, got %v", name, m["http"])
+		}
+		if m["ip"] != "10.0.0.1" || m["digest"] != "dead" || m["raw"] != "payload" || m["tenant"] != "t1" {
+			t.Fatalf("%s: unexpected fields: %v", name, m)
+		}
+		if tags, _ := m["tags"].([]interface{}); len(tags) != 2 {
+			t.Fatalf("%s: expected string slice, got %v", name, m["tags"])
+		}
+		if codes, _ := m["codes"].([]interface{}); len(codes) != 2 || codes[1] != float64(404) {
+			t.Fatalf("%s: expected int slice, got %v", name, m["codes"])
+		}
+	}
+
+	var z map[string]interface{}
+	_ = json.Unmarshal(zbuf.Bytes(), &z)
+	if z["elapsed"] != float64(1500) || z["at"] != "2024-01-02T03:04:05Z" {
+		t.Fatalf("zerolog: expected duration in ms and RFC3339 time, got %v / %v", z["elapsed"], z["at"])
+	}
+}
+
+func TestRichEventFieldsOnDisabledAndNopEvents(t *testing.T) {
+	buf := &bytes.Buffer{}
+	l := adapter.NewLog(buf, logger.ErrorLevel)
+	called := false
+	l.Info().Dict("d", func(logger.LogEvent) { called = true }).Msg("filtered")
+	if called || buf.Len() != 0 {
+		t.Fatalf("disabled events must not evaluate Dict nor write, got %q", buf.String())
+	}
+
+	// the global logger falls back to the nop logger.
+	prev := logger.GetGlobal()
+	defer logger.SetGlobal(prev)
+	logger.SetGlobal(nil)
+	logRichEvent(logger.GetGlobal())
+}
@@ -0,0 +1,9 @@
+package log
+
+import "testing"
+
+// RestoreContextExtractors snapshots the extractor registry and restores it,
+// order included, when t finishes.
+func RestoreContextExtractors(t testing.TB) {
+	t.Cleanup(extractors.Save())
+}
@@ -2,7 +2,10 @@ package log
 
 import (
 	"context"
+	"fmt"
+	"strings"
 	"sync/atomic"
+	"time"
 )
 
 // LogEvent é a interface fluente para construir logs encadeados (similar a zerolog.Event).
@@ -17,6 +20,22 @@ type LogEvent interface {
 	Float32(k string, v float32) LogEvent
 	Float64(k string, v float64) LogEvent
 	Interface(k string, v interface{}) LogEvent
+	// Dur adds a duration (rendered in milliseconds by the zerolog backend).
+	Dur(k string, v time.Duration) LogEvent
+	Time(k string, v time.Time) LogEvent
+	Strs(k string, v []string) LogEvent
+	Ints(k string, v []int) LogEvent
+	// Stringer adds v.String(), or null when v is nil.
+	Stringer(k string, v fmt.Stringer) LogEvent
+	// Hex adds v hex-encoded; Bytes adds v as a string.
+	Hex(k string, v []byte) LogEvent
+	Bytes(k string, v []byte) LogEvent
+	// Dict adds a nested object whose fields are set by fn on a sub-event.
+	// Msg/Msgf/Write on the sub-event are ignored, and fn is not called
+	// when the event is disabled.
+	Dict(k string, fn func(d LogEvent)) LogEvent
+	// Fields adds every entry of fields (keys sorted for stable output).
+	Fields(fields map[string]interface{}) LogEvent
 	Err(err error) LogEvent
 	Msg(msg string)
 	Msgf(format string, args ...interface{})
@@ -34,12 +53,29 @@ type LoggerFacade interface {
 	// Event builders for fluent logs. These return an Event that can be
 	// chained (Str/Float64/Interface/Err/Msg...). Use these when you prefer
 	// the zerolog-like fluent API.
+	Trace() LogEvent
 	Debug() LogEvent
 	Info() LogEvent
 	Warn() LogEvent
 	// Error accepts an optional error that will be attached to the event and
 	// returns a LogEvent for chaining.
 	Error(err error) LogEvent
+	// Fatal behaves like Error but terminates the process (os.Exit(1)) after
+	// the message is written by Msg/Msgf.
+	Fatal(err error) LogEvent
+	// Panic behaves like Error but panics with the message after it is
+	// written by Msg/Msgf.
+	Panic(err error) LogEvent
+
+	// Enabled reports whether events at the given level are emitted. Use it
+	// to skip building expensive fields for filtered levels.
+	Enabled(level Level) bool
+
+	// SetLevel changes the minimum level at runtime. The level is shared with
+	// the logger this one was derived from (and every logger derived from it).
+	SetLevel(level Level)
+	// GetLevel returns the effective level of this logger.
+	GetLevel() Level
 }
 
 // defaultAdapter is a minimal in-package adapter used as the package default.
@@ -53,13 +89,54 @@ type LoggerFacade interface {
 // so callers don't need to import zerolog directly.
 type Level int
 
+// TraceLevel sits below DebugLevel so the values of the original levels
+// (DebugLevel == 0) are preserved.
 const (
-	DebugLevel Level = iota
+	TraceLevel Level = iota - 1
+	DebugLevel
 	InfoLevel
 	WarnLevel
 	ErrorLevel
+	FatalLevel
+	PanicLevel
+)
+
+// Format represents the output encoding used by a logger backend. Like Level,
+// it is declared here so callers can select a format without importing the
+// concrete implementation.
+type Format string
+
+const (
+	// FormatJSON emits one JSON object per line (level, message, time).
+	FormatJSON Format = "json"
+	// FormatConsole emits human-readable (optionally colored) lines for local development.
+	FormatConsole Format = "console"
+	// FormatLogfmt emits key=value pairs (time, level, msg first).
+	FormatLogfmt Format = "logfmt"
+	// FormatECS emits JSON using Elastic Common Schema field names.
+	FormatECS Format = "ecs"
+	// FormatOTel emits JSON using the OpenTelemetry log data model field names.
+	FormatOTel Format = "otel"
 )
 
+// ParseFormat converts a textual format (case-insensitive) into a Format. The
+// boolean is false when the value is not recognized.
+func ParseFormat(s string) (Format, bool) {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "json":
+		return FormatJSON, true
+	case "console", "text", "pretty":
+		return FormatConsole, true
+	case "logfmt":
+		return FormatLogfmt, true
+	case "ecs":
+		return FormatECS, true
+	case "otel", "opentelemetry":
+		return FormatOTel, true
+	}
+	return "", false
+}
+
 // ctxKey is used for storing values in context without colliding with other packages.
 type ctxKey string
 
@@ -84,29 +161,44 @@ func SetGlobal(l LoggerFacade) { globalLogger.Store(&storedLogger{lf: l}) }
 // nop implementations used as safe fallbacks when no global logger is set.
 type nopEvent struct{}
 
-func (nopEvent) Str(k, v string) LogEvent                   { return nopEvent{} }
-func (nopEvent) Int(k string, v int) LogEvent               { return nopEvent{} }
-func (nopEvent) Int64(k string, v int64) LogEvent           { return nopEvent{} }
-func (nopEvent) Uint(k string, v uint) LogEvent             { return nopEvent{} }
-func (nopEvent) Uint64(k string, v uint64) LogEvent         { return nopEvent{} }
-func (nopEvent) Bool(k string, v bool) LogEvent             { return nopEvent{} }
-func (nopEvent) Float32(k string, v float32) LogEvent       { return nopEvent{} }
-func (nopEvent) Float64(k string, v float64) LogEvent       { return nopEvent{} }
-func (nopEvent) Interface(k string, v interface{}) LogEvent { return nopEvent{} }
-func (nopEvent) Err(err error) LogEvent                     { return nopEvent{} }
-func (nopEvent) Msg(msg string)                             {}
-func (nopEvent) Msgf(format string, args ...interface{})    {}
-func (nopEvent) Write(p []byte) (n int, err error)          { return 0, nil }
+func (nopEvent) Str(k, v string) LogEvent                      { return nopEvent{} }
+func (nopEvent) Int(k string, v int) LogEvent                  { return nopEvent{} }
+func (nopEvent) Int64(k string, v int64) LogEvent              { return nopEvent{} }
+func (nopEvent) Uint(k string, v uint) LogEvent                { return nopEvent{} }
+func (nopEvent) Uint64(k string, v uint64) LogEvent            { return nopEvent{} }
+func (nopEvent) Bool(k string, v bool) LogEvent                { return nopEvent{} }
+func (nopEvent) Float32(k string, v float32) LogEvent          { return nopEvent{} }
+func (nopEvent) Float64(k string, v float64) LogEvent          { return nopEvent{} }
+func (nopEvent) Interface(k string, v interface{}) LogEvent    { return nopEvent{} }
+func (nopEvent) Dur(k string, v time.Duration) LogEvent        { return nopEvent{} }
+func (nopEvent) Time(k string, v time.Time) LogEvent           { return nopEvent{} }
+func (nopEvent) Strs(k string, v []string) LogEvent            { return nopEvent{} }
+func (nopEvent) Ints(k string, v []int) LogEvent               { return nopEvent{} }
+func (nopEvent) Stringer(k string, v fmt.Stringer) LogEvent    { return nopEvent{} }
+func (nopEvent) Hex(k string, v []byte) LogEvent               { return nopEvent{} }
+func (nopEvent) Bytes(k string, v []byte) LogEvent             { return nopEvent{} }
+func (nopEvent) Dict(k string, fn func(d LogEvent)) LogEvent   { return nopEvent{} }
+func (nopEvent) Fields(fields map[string]interface{}) LogEvent { return nopEvent{} }
+func (nopEvent) Err(err error) LogEvent                        { return nopEvent{} }
+func (nopEvent) Msg(msg string)                                {}
+func (nopEvent) Msgf(format string, args ...interface{})       {}
+func (nopEvent) Write(p []byte) (n int, err error)             { return 0, nil }
 
 type nopLogger struct{}
 
 func (nopLogger) WithField(k string, v interface{}) LoggerFacade        { return nopLogger{} }
 func (nopLogger) WithFields(fields map[string]interface{}) LoggerFacade { return nopLogger{} }
 func (nopLogger) WithTraceFromContext(ctx context.Context) LoggerFacade { return nopLogger{} }
+func (nopLogger) Trace() LogEvent                                       { return nopEvent{} }
 func (nopLogger) Debug() LogEvent                                       { return nopEvent{} }
 func (nopLogger) Info() LogEvent                                        { return nopEvent{} }
 func (nopLogger) Warn() LogEvent                                        { return nopEvent{} }
 func (nopLogger) Error(err error) LogEvent                              { return nopEvent{} }
+func (nopLogger) Fatal(err error) LogEvent                              { return nopEvent{} }
+func (nopLogger) Panic(err error) LogEvent                              { return nopEvent{} }
+func (nopLogger) Enabled(level Level) bool                              { return false }
+func (nopLogger) SetLevel(level Level)                                  {}
+func (nopLogger) GetLevel() Level                                       { return InfoLevel }
 
 // GetGlobal returns the package-level global logger. If none is configured
 // yet, it stores and returns a no-op logger to avoid nil panics.
@@ -124,10 +216,22 @@ func GetGlobal() LoggerFacade {
 }
 
 // Package-level helpers for fluent events
+func Trace() LogEvent          { return GetGlobal().Trace() }
 func Debug() LogEvent          { return GetGlobal().Debug() }
 func Info() LogEvent           { return GetGlobal().Info() }
 func Warn() LogEvent           { return GetGlobal().Warn() }
 func Error(err error) LogEvent { return GetGlobal().Error(err) }
+func Fatal(err error) LogEvent { return GetGlobal().Fatal(err) }
+func Panic(err error) LogEvent { return GetGlobal().Panic(err) }
+
+// Enabled reports whether the global logger emits events at the given level.
+func Enabled(level Level) bool { return GetGlobal().Enabled(level) }
+
+// SetLevel changes the level of the global logger at runtime.
+func SetLevel(level Level) { GetGlobal().SetLevel(level) }
+
+// GetLevel returns the level of the global logger.
+func GetLevel() Level { return GetGlobal().GetLevel() }
 
 // ContextWithLogger stores a LoggerFacade in the context so callers can inject
 // a logger instance (facade) that will be used by library code.
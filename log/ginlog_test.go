@@ -0,0 +1,73 @@
+package log_test
+
+import (
+	"bytes"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	logger "github.com/totvs/go-sdk/log"
+	adapter "github.com/totvs/go-sdk/log/adapter"
+	middleware "github.com/totvs/go-sdk/log/middleware"
+	"github.com/totvs/go-sdk/log/middleware/ginlog"
+	tr "github.com/totvs/go-sdk/trace"
+)
+
+func TestGinMiddlewareLogsCompletion(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	buf := &bytes.Buffer{}
+
+	r := gin.New()
+	r.Use(ginlog.MiddlewareWithLogger(adapter.NewLog(buf, logger.InfoLevel)))
+	r.GET("/items/:id", func(c *gin.Context) {
+		_, inGin := c.Get(ginlog.LoggerKey)
+		_, inReq := logger.LoggerFromContext(c.Request.Context())
+		if _, logged := middleware.GetLoggerFromRequest(c.Request); !inGin || !inReq || !logged {
+			t.Error("expected the request logger in gin.Context and in the request context")
+		}
+		if tr.TraceIDFromContext(c.Request.Context()) != "gin-123" {
+			t.Error("expected the trace id in the request context")
+		}
+		ginlog.GetLogger(c).Info().Msg("handling")
+		_ = c.Error(errors.New("cache unavailable"))
+		c.String(http.StatusBadGateway, "upstream")
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/items/42", nil)
+	req.Header.Set(tr.TraceIDHTTPCorrelationHeader, "gin-123")
+	req.RemoteAddr = "192.0.2.1:9999"
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, req)
+
+	if rec.Header().Get(tr.TraceIDHTTPHeader) != "gin-123" {
+		t.Fatalf("expected the trace header in the response, got %v", rec.Header())
+	}
+	m := findLine(buf.String(), "http request completed")
+	if m == nil {
+		t.Fatalf("expected a completion event, got: %s", buf.String())
+	}
+	errs, _ := m["errors"].([]interface{})
+	if m["route"] != "/items/:id" || m["path"] != "/items/42" || m["status"] != float64(502) || m["level"] != "error" ||
+		m["bytes"] != float64(8) || m["client_ip"] != "192.0.2.1" || m[tr.TraceIDField] != "gin-123" ||
+		len(errs) != 1 || errs[0] != "cache unavailable" {
+		t.Fatalf("unexpected completion fields: %v", m)
+	}
+}
+
+func TestGinMiddlewareSkipPaths(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	buf := &bytes.Buffer{}
+	opts := ginlog.DefaultOptions
+	opts.SkipPaths = []string{"/healthz"}
+
+	r := gin.New()
+	r.Use(ginlog.MiddlewareWithOptions(adapter.NewLog(buf, logger.InfoLevel), opts))
+	r.GET("/healthz", func(c *gin.Context) { c.Status(http.StatusOK) })
+	r.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/healthz", nil))
+
+	if buf.Len() != 0 {
+		t.Fatalf("expected skipped paths not to be logged, got: %s", buf.String())
+	}
+}
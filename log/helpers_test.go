@@ -0,0 +1,28 @@
+package log_test
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+// decodeLine decodes a single JSON log line, failing the test when it is invalid.
+func decodeLine(t *testing.T, b []byte) map[string]interface{} {
+	t.Helper()
+	var m map[string]interface{}
+	if err := json.Unmarshal(b, &m); err != nil {
+		t.Fatalf("invalid json: %v, raw: %s", err, b)
+	}
+	return m
+}
+
+// findLine returns the first JSON line of out logged with message, or nil.
+func findLine(out, message string) map[string]interface{} {
+	for _, line := range strings.Split(strings.TrimSpace(out), "\n") {
+		var m map[string]interface{}
+		if err := json.Unmarshal([]byte(line), &m); err == nil && m["message"] == message {
+			return m
+		}
+	}
+	return nil
+}
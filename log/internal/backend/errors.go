@@ -0,0 +1,79 @@
+package backend
+
+import (
+	"fmt"
+	"runtime"
+	"strconv"
+	"strings"
+
+	"github.com/rs/zerolog"
+	lg "github.com/totvs/go-sdk/log"
+)
+
+// maxErrorDepth bounds the rendered error tree so pathological chains (or
+// errors that unwrap to themselves) cannot blow up a log line.
+const maxErrorDepth = 16
+
+// maxStackFrames bounds the captured stack trace.
+const maxStackFrames = 32
+
+// errorNode renders an error and its Unwrap/Join chain as nested objects:
+// {"type", "message", "fields", "cause"} or {..., "causes": [...]}.
+type errorNode struct {
+	err   error
+	depth int
+}
+
+func (n errorNode) MarshalZerologObject(e *zerolog.Event) {
+	e.Str("type", fmt.Sprintf("%T", n.err)).Str("message", n.err.Error())
+	if f, ok := n.err.(lg.ErrorFielder); ok {
+		if fields := f.ErrorFields(); len(fields) > 0 {
+			e.Dict("fields", zerolog.Dict().Fields(fields))
+		}
+	}
+	if n.depth+1 >= maxErrorDepth {
+		return
+	}
+	switch u := n.err.(type) {
+	case interface{ Unwrap() []error }:
+		arr := zerolog.Arr()
+		for _, c := range u.Unwrap() {
+			if c != nil {
+				arr = arr.Object(errorNode{err: c, depth: n.depth + 1})
+			}
+		}
+		e.Array("causes", arr)
+	case interface{ Unwrap() error }:
+		if c := u.Unwrap(); c != nil {
+			e.Object("cause", errorNode{err: c, depth: n.depth + 1})
+		}
+	}
+}
+
+// captureStack returns the stack as "function\n\tfile:line" lines, starting
+// skip frames above the caller of captureStack.
+func captureStack(skip int) string {
+	pcs := make([]uintptr, maxStackFrames)
+	n := runtime.Callers(skip+2, pcs)
+	frames := runtime.CallersFrames(pcs[:n])
+	var b strings.Builder
+	for {
+		f, more := frames.Next()
+		if f.Function == "" || strings.HasPrefix(f.Function, "runtime.") {
+			if !more {
+				break
+			}
+			continue
+		}
+		b.WriteString(f.Function)
+		b.WriteString("\n\t")
+		b.WriteString(f.File)
+		b.WriteByte(':')
+		b.WriteString(strconv.Itoa(f.Line))
+		b.WriteByte('\n')
+		if !more {
+			break
+		}
+	}
+	return b.String()
+}
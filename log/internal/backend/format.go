@@ -0,0 +1,273 @@
+package backend
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog"
+	lg "github.com/totvs/go-sdk/log"
+	"github.com/totvs/go-sdk/trace"
+)
+
+// ecsVersion is the Elastic Common Schema version advertised in ECS output.
+const ecsVersion = "8.11.0"
+
+// ecsFieldNames maps the zerolog field names to their ECS counterparts.
+var ecsFieldNames = map[string]string{
+	zerolog.TimestampFieldName:  "@timestamp",
+	zerolog.LevelFieldName:      "log.level",
+	zerolog.MessageFieldName:    "message",
+	zerolog.ErrorFieldName:      "error.message",
+	zerolog.CallerFieldName:     "log.origin.file.name",
+	zerolog.ErrorStackFieldName: "error.stack_trace",
+	trace.TraceIDField:          "trace.id",
+	trace.SpanIDField:           "span.id",
+	lg.LoggerNameField:          "log.logger",
+}
+
+// logfmtFieldNames follows the usual logfmt conventions (`msg` instead of `message`).
+var logfmtFieldNames = map[string]string{
+	zerolog.MessageFieldName: "msg",
+}
+
+// otelSeverity maps zerolog level names to OpenTelemetry severity numbers.
+var otelSeverity = map[string]int{
+	"trace": 1,
+	"debug": 5,
+	"info":  9,
+	"warn":  13,
+	"error": 17,
+	"fatal": 21,
+	"panic": 24,
+}
+
+// field is a top-level key/value pair of a JSON log line. The value is kept
+// raw so re-encoding never changes its representation.
+type field struct {
+	key string
+	val json.RawMessage
+}
+
+// newFormatWriter wraps w so the JSON lines produced by zerolog are rendered
+// in the configured format. Plain JSON without renames is returned unwrapped
+// to keep the default path allocation-free.
+func newFormatWriter(w io.Writer, cfg config) io.Writer {
+	switch cfg.format {
+	case lg.FormatConsole:
+		return zerolog.ConsoleWriter{Out: w, NoColor: cfg.noColor, TimeFormat: time.RFC3339}
+	case lg.FormatLogfmt:
+		return &formatWriter{w: w, format: cfg.format, names: mergeNames(logfmtFieldNames, cfg.fieldNames)}
+	case lg.FormatECS:
+		return &formatWriter{w: w, format: cfg.format, names: mergeNames(ecsFieldNames, cfg.fieldNames)}
+	case lg.FormatOTel:
+		return &formatWriter{w: w, format: cfg.format, names: cfg.fieldNames}
+	default:
+		if len(cfg.fieldNames) == 0 {
+			return w
+		}
+		return &formatWriter{w: w, format: lg.FormatJSON, names: cfg.fieldNames}
+	}
+}
+
+func mergeNames(base, overrides map[string]string) map[string]string {
+	out := make(map[string]string, len(base)+len(overrides))
+	for k, v := range base {
+		out[k] = v
+	}
+	for k, v := range overrides {
+		out[k] = v
+	}
+	return out
+}
+
+// formatWriter re-encodes each JSON line written by zerolog.
+type formatWriter struct {
+	w      io.Writer
+	format lg.Format
+	names  map[string]string
+}
+
+func (f *formatWriter) Write(p []byte) (int, error) {
+	fields, ok := decodeFields(p)
+	if !ok {
+		// not a JSON object (e.g. raw bytes written by a caller): pass through.
+		return f.w.Write(p)
+	}
+	var buf bytes.Buffer
+	switch f.format {
+	case lg.FormatLogfmt:
+		encodeLogfmt(&buf, f.rename(fields))
+	case lg.FormatOTel:
+		encodeJSON(&buf, f.otel(fields))
+	case lg.FormatECS:
+		fields = append(f.rename(fields), field{key: "ecs.version", val: json.RawMessage(strconv.Quote(ecsVersion))})
+		encodeJSON(&buf, fields)
+	default:
+		encodeJSON(&buf, f.rename(fields))
+	}
+	if _, err := f.w.Write(buf.Bytes()); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+// rename applies the field mapping and moves the time, level and message
+// fields to the front so every format starts with the same well-known keys.
+func (f *formatWriter) rename(fields []field) []field {
+	out := make([]field, 0, len(fields))
+	var head [3]*field
+	for i, fd := range fields {
+		switch fd.key {
+		case zerolog.TimestampFieldName:
+			head[0] = &fields[i]
+		case zerolog.LevelFieldName:
+			head[1] = &fields[i]
+		case zerolog.MessageFieldName:
+			head[2] = &fields[i]
+		default:
+			out = append(out, field{key: f.name(fd.key), val: fd.val})
+		}
+	}
+	lead := make([]field, 0, len(out)+3)
+	for _, h := range head {
+		if h != nil {
+			lead = append(lead, field{key: f.name(h.key), val: h.val})
+		}
+	}
+	return append(lead, out...)
+}
+
+func (f *formatWriter) name(k string) string {
+	if n, ok := f.names[k]; ok && n != "" {
+		return n
+	}
+	return k
+}
+
+// otel maps a zerolog line to the OpenTelemetry log data model: well-known
+// fields become top-level (Timestamp, SeverityText, SeverityNumber, Body,
+// TraceId, SpanId) and everything else is nested under Attributes.
+func (f *formatWriter) otel(fields []field) []field {
+	var (
+		ts, body, traceID, spanID json.RawMessage
+		attrs                     []field
+		level                     string
+	)
+	for _, fd := range fields {
+		switch fd.key {
+		case zerolog.TimestampFieldName:
+			ts = fd.val
+		case zerolog.LevelFieldName:
+			_ = json.Unmarshal(fd.val, &level)
+		case zerolog.MessageFieldName:
+			body = fd.val
+		case trace.TraceIDField:
+			traceID = fd.val
+		case trace.SpanIDField:
+			spanID = fd.val
+		case zerolog.ErrorFieldName:
+			attrs = append(attrs, field{key: f.name("exception.message"), val: fd.val})
+		default:
+			attrs = append(attrs, field{key: f.name(fd.key), val: fd.val})
+		}
+	}
+	out := make([]field, 0, 7)
+	add := func(k string, v json.RawMessage) {
+		if v != nil {
+			out = append(out, field{key: k, val: v})
+		}
+	}
+	add("Timestamp", ts)
+	if level != "" {
+		add("SeverityText", json.RawMessage(strconv.Quote(strings.ToUpper(level))))
+		add("SeverityNumber", json.RawMessage(strconv.Itoa(otelSeverity[level])))
+	}
+	add("Body", body)
+	add("TraceId", traceID)
+	add("SpanId", spanID)
+	if len(attrs) > 0 {
+		var buf bytes.Buffer
+		encodeObject(&buf, attrs)
+		add("Attributes", buf.Bytes())
+	}
+	return out
+}
+
+// decodeFields splits a JSON object into its top-level fields preserving order.
+func decodeFields(p []byte) ([]field, bool) {
+	dec := json.NewDecoder(bytes.NewReader(p))
+	tok, err := dec.Token()
+	if err != nil {
+		return nil, false
+	}
+	if d, ok := tok.(json.Delim); !ok || d != '{' {
+		return nil, false
+	}
+	var fields []field
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return nil, false
+		}
+		key, ok := tok.(string)
+		if !ok {
+			return nil, false
+		}
+		var raw json.RawMessage
+		if err := dec.Decode(&raw); err != nil {
+			return nil, false
+		}
+		fields = append(fields, field{key: key, val: raw})
+	}
+	return fields, true
+}
+
+func encodeObject(buf *bytes.Buffer, fields []field) {
+	buf.WriteByte('{')
+	for i, fd := range fields {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		k, _ := json.Marshal(fd.key)
+		buf.Write(k)
+		buf.WriteByte(':')
+		buf.Write(fd.val)
+	}
+	buf.WriteByte('}')
+}
+
+func encodeJSON(buf *bytes.Buffer, fields []field) {
+	encodeObject(buf, fields)
+	buf.WriteByte('\n')
+}
+
+func encodeLogfmt(buf *bytes.Buffer, fields []field) {
+	for i, fd := range fields {
+		if i > 0 {
+			buf.WriteByte(' ')
+		}
+		buf.WriteString(fd.key)
+		buf.WriteByte('=')
+		buf.WriteString(logfmtValue(fd.val))
+	}
+	buf.WriteByte('\n')
+}
+
+// logfmtValue renders a raw JSON value: strings are unquoted when safe,
+// scalars are written as-is and objects/arrays are written as quoted JSON.
+func logfmtValue(raw json.RawMessage) string {
+	s := string(raw)
+	if len(raw) > 0 && raw[0] == '"' {
+		if err := json.Unmarshal(raw, &s); err != nil {
+			s = string(raw)
+		}
+	}
+	if s == "" || strings.ContainsAny(s, " =\"\\\t\r\n") {
+		return strconv.Quote(s)
+	}
+	return s
+}
@@ -0,0 +1,92 @@
+package backend
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+	lg "github.com/totvs/go-sdk/log"
+)
+
+var update = flag.Bool("update", false, "update golden files")
+
+// fixedTime makes timestamps deterministic so outputs can be compared with
+// golden files.
+func fixedTime(t *testing.T) {
+	t.Helper()
+	prev := zerolog.TimestampFunc
+	zerolog.TimestampFunc = func() time.Time { return time.Date(2025, 9, 5, 12, 0, 0, 0, time.UTC) }
+	t.Cleanup(func() { zerolog.TimestampFunc = prev })
+}
+
+func emitSample(l lg.LoggerFacade) {
+	l = l.WithFields(map[string]interface{}{"trace_id": "9f3b2c1a4d5e6f708192a3b4c5d6e7f8"})
+	l.Info().Str("service", "orders").Int("attempt", 2).Msg("request processed")
+	l.Warn().Str("path", "/a b").Bool("retry", true).Msg("slow=request")
+	l.Error(errors.New("boom")).Interface("obj", map[string]string{"a": "b"}).Msg("failed")
+}
+
+func TestFormatGolden(t *testing.T) {
+	cases := []struct {
+		name string
+		opts []Option
+	}{
+		{name: "json", opts: []Option{WithFormat(lg.FormatJSON)}},
+		{name: "console", opts: []Option{WithFormat(lg.FormatConsole), WithNoColor()}},
+		{name: "logfmt", opts: []Option{WithFormat(lg.FormatLogfmt)}},
+		{name: "ecs", opts: []Option{WithFormat(lg.FormatECS)}},
+		{name: "otel", opts: []Option{WithFormat(lg.FormatOTel)}},
+		{name: "json_renamed", opts: []Option{WithFieldNames(map[string]string{"message": "msg", "time": "ts"})}},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			fixedTime(t)
+			buf := &bytes.Buffer{}
+			emitSample(NewLog(buf, lg.DebugLevel, tc.opts...))
+
+			golden := filepath.Join("testdata", tc.name+".golden")
+			if *update {
+				if err := os.WriteFile(golden, buf.Bytes(), 0o644); err != nil {
+					t.Fatalf("write golden: %v", err)
+				}
+			}
+			want, err := os.ReadFile(golden)
+			if err != nil {
+				t.Fatalf("read golden: %v", err)
+			}
+			if !bytes.Equal(buf.Bytes(), want) {
+				t.Fatalf("output mismatch for %s\n got:\n%s\nwant:\n%s", tc.name, buf.String(), want)
+			}
+		})
+	}
+}
+
+func TestFormatPassThroughNonJSON(t *testing.T) {
+	buf := &bytes.Buffer{}
+	w := newFormatWriter(buf, config{format: lg.FormatLogfmt})
+	if _, err := w.Write([]byte("plain text\n")); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if buf.String() != "plain text\n" {
+		t.Fatalf("expected pass-through, got: %q", buf.String())
+	}
+}
+
+func TestDefaultLogReadsFormatFromEnv(t *testing.T) {
+	t.Setenv("LOG_FORMAT", "logfmt")
+	cfg := newConfig(envOptions())
+	if cfg.format != lg.FormatLogfmt {
+		t.Fatalf("expected logfmt, got: %s", cfg.format)
+	}
+
+	// explicit options take precedence over the environment
+	cfg = newConfig(append(envOptions(), WithFormat(lg.FormatECS)))
+	if cfg.format != lg.FormatECS {
+		t.Fatalf("expected ecs, got: %s", cfg.format)
+	}
+}
@@ -0,0 +1,121 @@
+package backend
+
+import (
+	"os"
+
+	lg "github.com/totvs/go-sdk/log"
+)
+
+// Option customizes the logger built by NewLog/NewDefaultLog.
+type Option func(*config)
+
+// config groups the optional settings of the zerolog backend.
+type config struct {
+	format     lg.Format
+	noColor    bool
+	fieldNames map[string]string
+	level      *lg.AtomicLevel
+	sampling   *SamplingConfig
+	redaction  *RedactionConfig
+	caller     bool
+	stack      bool
+	errorChain bool
+}
+
+func newConfig(opts []Option) config {
+	cfg := config{format: lg.FormatJSON}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(&cfg)
+		}
+	}
+	return cfg
+}
+
+// envOptions returns the options derived from environment variables
+// (LOG_FORMAT, NO_COLOR). They are applied before explicit options so callers
+// can always override the environment.
+func envOptions() []Option {
+	var opts []Option
+	if f, ok := lg.ParseFormat(os.Getenv("LOG_FORMAT")); ok {
+		opts = append(opts, WithFormat(f))
+	}
+	if os.Getenv("NO_COLOR") != "" {
+		opts = append(opts, WithNoColor())
+	}
+	return opts
+}
+
+// WithFormat selects the output format. Unknown formats fall back to JSON.
+func WithFormat(f lg.Format) Option {
+	return func(c *config) {
+		if f != "" {
+			c.format = f
+		}
+	}
+}
+
+// WithNoColor disables ANSI colors in the console format.
+func WithNoColor() Option {
+	return func(c *config) { c.noColor = true }
+}
+
+// WithFieldNames renames top-level fields in the output. Keys are the default
+// field names (`level`, `message`, `time`, `error`, `trace_id`, ...) and values
+// are the names to emit. Renames are applied on top of the format mapping and
+// are ignored by the console format.
+func WithFieldNames(names map[string]string) Option {
+	return func(c *config) {
+		if len(names) == 0 {
+			return
+		}
+		if c.fieldNames == nil {
+			c.fieldNames = make(map[string]string, len(names))
+		}
+		for k, v := range names {
+			c.fieldNames[k] = v
+		}
+	}
+}
+
+// WithAtomicLevel makes the logger use a shared level holder, so the level
+// can be changed at runtime (e.g. through util.NewLevelHandler) and shared
+// between independently constructed loggers. The level passed to the
+// constructor is ignored when a holder is supplied.
+func WithAtomicLevel(level *lg.AtomicLevel) Option {
+	return func(c *config) {
+		if level != nil {
+			c.level = level
+		}
+	}
+}
+
+// WithSampling enables event sampling and burst limiting (see SamplingConfig).
+// The limits are shared by every logger derived from the one being built.
+func WithSampling(cfg SamplingConfig) Option {
+	return func(c *config) { c.sampling = &cfg }
+}
+
+// WithRedaction masks sensitive values (see RedactionConfig) before events
+// reach the output format and the destination writer.
+func WithRedaction(cfg RedactionConfig) Option {
+	return func(c *config) { c.redaction = &cfg }
+}
+
+// WithCaller adds the caller location (`caller`: file:line) to every event.
+func WithCaller() Option {
+	return func(c *config) { c.caller = true }
+}
+
+// WithStackTrace adds the stack trace of the call site (`stack`) to Error,
+// Fatal and Panic events.
+func WithStackTrace() Option {
+	return func(c *config) { c.stack = true }
+}
+
+// WithErrorChain renders the error passed to Error/Fatal/Panic, including its
+// errors.Unwrap/errors.Join chain, types and lg.ErrorFielder fields, as a
+// nested object under lg.ErrorChainField. The `error` message is kept.
+func WithErrorChain() Option {
+	return func(c *config) { c.errorChain = true }
+}
@@ -0,0 +1,239 @@
+package backend
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"path"
+	"regexp"
+	"strings"
+)
+
+// DefaultRedactionMask replaces redacted values when RedactionConfig.Mask is empty.
+const DefaultRedactionMask = "[REDACTED]"
+
+// DefaultRedactedKeys are the field-name patterns redacted by
+// DefaultRedactionConfig. Patterns use path.Match syntax and are matched
+// case-insensitively against every (nested) field name.
+var DefaultRedactedKeys = []string{
+	"authorization", "proxy-authorization", "cookie", "set-cookie",
+	"*password*", "*passwd*", "*secret*", "*token*",
+	"*api_key*", "*api-key*", "*apikey*",
+	"cpf", "cnpj", "email", "e-mail",
+}
+
+// Detector finds sensitive data inside string values regardless of the field
+// name. Every match of Pattern is replaced by the mask unless Validate is set
+// and rejects the match.
+type Detector struct {
+	Name     string
+	Pattern  *regexp.Regexp
+	Validate func(match string) bool
+}
+
+// Built-in detectors.
+var (
+	DetectBearer = Detector{Name: "bearer", Pattern: regexp.MustCompile(`(?i)\bbearer\s+[A-Za-z0-9\-._~+/]+=*`)}
+	DetectJWT    = Detector{Name: "jwt", Pattern: regexp.MustCompile(`\beyJ[A-Za-z0-9_-]+\.[A-Za-z0-9_-]+\.[A-Za-z0-9_-]*`)}
+	DetectEmail  = Detector{Name: "email", Pattern: regexp.MustCompile(`[A-Za-z0-9._%+-]+@[A-Za-z0-9.-]+\.[A-Za-z]{2,}`)}
+	DetectCPF    = Detector{Name: "cpf", Pattern: regexp.MustCompile(`\b\d{3}\.?\d{3}\.?\d{3}-?\d{2}\b`), Validate: validCPF}
+	DetectCNPJ   = Detector{Name: "cnpj", Pattern: regexp.MustCompile(`\b\d{2}\.?\d{3}\.?\d{3}/?\d{4}-?\d{2}\b`), Validate: validCNPJ}
+)
+
+// RedactionConfig configures the redaction layer. Values of fields whose name
+// matches Keys are fully masked; string values anywhere in the event
+// (including the message and error text) are scanned by Detectors.
+type RedactionConfig struct {
+	Keys      []string
+	Detectors []Detector
+	Mask      string
+}
+
+// DefaultRedactionConfig returns the recommended configuration: credentials,
+// tokens and personal data keys plus the JWT, bearer, e-mail, CPF and CNPJ
+// detectors.
+func DefaultRedactionConfig() RedactionConfig {
+	return RedactionConfig{
+		Keys:      append([]string(nil), DefaultRedactedKeys...),
+		Detectors: []Detector{DetectBearer, DetectJWT, DetectEmail, DetectCNPJ, DetectCPF},
+		Mask:      DefaultRedactionMask,
+	}
+}
+
+// redactWriter masks sensitive values of each JSON line before handing it to
+// the next writer (the format writer or the destination).
+type redactWriter struct {
+	w         io.Writer
+	keys      []string
+	detectors []Detector
+	mask      json.RawMessage
+	maskStr   string
+}
+
+func newRedactWriter(w io.Writer, cfg RedactionConfig) io.Writer {
+	mask := cfg.Mask
+	if mask == "" {
+		mask = DefaultRedactionMask
+	}
+	keys := make([]string, len(cfg.Keys))
+	for i, k := range cfg.Keys {
+		keys[i] = strings.ToLower(k)
+	}
+	return &redactWriter{w: w, keys: keys, detectors: cfg.Detectors, mask: encodeString(mask), maskStr: mask}
+}
+
+func (r *redactWriter) Write(p []byte) (int, error) {
+	fields, ok := decodeFields(p)
+	if !ok {
+		// raw output: only detectors can be applied.
+		if _, err := io.WriteString(r.w, r.scan(string(p))); err != nil {
+			return 0, err
+		}
+		return len(p), nil
+	}
+	var buf bytes.Buffer
+	encodeJSON(&buf, r.fields(fields))
+	if _, err := r.w.Write(buf.Bytes()); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+func (r *redactWriter) fields(fields []field) []field {
+	for i, fd := range fields {
+		if r.sensitiveKey(fd.key) {
+			fields[i].val = r.mask
+			continue
+		}
+		fields[i].val = r.value(fd.val)
+	}
+	return fields
+}
+
+func (r *redactWriter) value(raw json.RawMessage) json.RawMessage {
+	if len(raw) == 0 {
+		return raw
+	}
+	switch raw[0] {
+	case '{':
+		fields, ok := decodeFields(raw)
+		if !ok {
+			return raw
+		}
+		var buf bytes.Buffer
+		encodeObject(&buf, r.fields(fields))
+		return buf.Bytes()
+	case '[':
+		var items []json.RawMessage
+		if err := json.Unmarshal(raw, &items); err != nil {
+			return raw
+		}
+		var buf bytes.Buffer
+		buf.WriteByte('[')
+		for i, it := range items {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			buf.Write(r.value(it))
+		}
+		buf.WriteByte(']')
+		return buf.Bytes()
+	case '"':
+		var s string
+		if err := json.Unmarshal(raw, &s); err != nil {
+			return raw
+		}
+		if out := r.scan(s); out != s {
+			return encodeString(out)
+		}
+	}
+	return raw
+}
+
+func (r *redactWriter) sensitiveKey(key string) bool {
+	k := strings.ToLower(key)
+	for _, pattern := range r.keys {
+		if ok, _ := path.Match(pattern, k); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// scan applies the detectors to s, replacing every validated match with the mask.
+func (r *redactWriter) scan(s string) string {
+	for _, d := range r.detectors {
+		if d.Pattern == nil {
+			continue
+		}
+		s = d.Pattern.ReplaceAllStringFunc(s, func(m string) string {
+			if d.Validate != nil && !d.Validate(m) {
+				return m
+			}
+			return r.maskStr
+		})
+	}
+	return s
+}
+
+// encodeString encodes s as a JSON string without HTML escaping, matching
+// the zerolog encoder.
+func encodeString(s string) json.RawMessage {
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	_ = enc.Encode(s)
+	return bytes.TrimRight(buf.Bytes(), "\n")
+}
+
+func onlyDigits(s string) []int {
+	out := make([]int, 0, len(s))
+	for _, c := range s {
+		if c >= '0' && c <= '9' {
+			out = append(out, int(c-'0'))
+		}
+	}
+	return out
+}
+
+func allEqual(d []int) bool {
+	for _, v := range d[1:] {
+		if v != d[0] {
+			return false
+		}
+	}
+	return true
+}
+
+// checkDigit computes a mod-11 check digit using the given weights.
+func checkDigit(d []int, weights []int) int {
+	sum := 0
+	for i, w := range weights {
+		sum += d[i] * w
+	}
+	if r := sum % 11; r >= 2 {
+		return 11 - r
+	}
+	return 0
+}
+
+// validCPF checks the two CPF verification digits, avoiding masking arbitrary
+// 11-digit numbers.
+func validCPF(s string) bool {
+	d := onlyDigits(s)
+	if len(d) != 11 || allEqual(d) {
+		return false
+	}
+	return checkDigit(d, []int{10, 9, 8, 7, 6, 5, 4, 3, 2}) == d[9] &&
+		checkDigit(d, []int{11, 10, 9, 8, 7, 6, 5, 4, 3, 2}) == d[10]
+}
+
+// validCNPJ checks the two CNPJ verification digits.
+func validCNPJ(s string) bool {
+	d := onlyDigits(s)
+	if len(d) != 14 || allEqual(d) {
+		return false
+	}
+	return checkDigit(d, []int{5, 4, 3, 2, 9, 8, 7, 6, 5, 4, 3, 2}) == d[12] &&
+		checkDigit(d, []int{6, 5, 4, 3, 2, 9, 8, 7, 6, 5, 4, 3, 2}) == d[13]
+}
@@ -0,0 +1,106 @@
+package backend
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	lg "github.com/totvs/go-sdk/log"
+	mt "github.com/totvs/go-sdk/metrics"
+)
+
+// DroppedEventsMetric is the counter incremented for every event discarded by
+// sampling or burst limiting. It carries the `level` and `reason`
+// (`sampled` or `rate_limited`) attributes.
+const DroppedEventsMetric = "log_events_dropped_total"
+
+const (
+	dropReasonSampled     = "sampled"
+	dropReasonRateLimited = "rate_limited"
+)
+
+// SamplingConfig configures event sampling and burst limiting. Events are
+// keyed by level and message (the format string for Msgf). Within each
+// Interval the first First events of a key are logged and, after that, every
+// Thereafter-th one. Burst caps the total number of events logged per
+// Interval across all keys. Fatal and Panic events are never dropped.
+type SamplingConfig struct {
+	// Interval is the window after which counters reset (default 1s).
+	Interval time.Duration
+	// First is the number of events per key always logged in each interval.
+	First int
+	// Thereafter logs every Nth event per key after First. Zero drops them all.
+	Thereafter int
+	// Burst limits the events logged per interval across all keys (0 = unlimited).
+	Burst int
+	// Metrics receives the dropped-events counter. Defaults to metrics.GetGlobal().
+	Metrics mt.MetricsFacade
+}
+
+// sampler applies a SamplingConfig. It is shared by all loggers derived from
+// the same root so limits apply to the whole logger family.
+type sampler struct {
+	cfg SamplingConfig
+	now func() time.Time
+
+	mu          sync.Mutex
+	windowStart time.Time
+	counts      map[string]int
+	total       int
+}
+
+func newSampler(cfg SamplingConfig) *sampler {
+	if cfg.Interval <= 0 {
+		cfg.Interval = time.Second
+	}
+	return &sampler{cfg: cfg, now: time.Now, counts: map[string]int{}}
+}
+
+// allow reports whether the event identified by level and msg must be
+// written, recording a dropped event otherwise.
+func (s *sampler) allow(level lg.Level, msg string) bool {
+	if s == nil || level >= lg.FatalLevel {
+		return true
+	}
+	ok, reason := s.decide(level, msg)
+	if !ok {
+		s.dropped(level, reason)
+	}
+	return ok
+}
+
+func (s *sampler) decide(level lg.Level, msg string) (bool, string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	if now.Sub(s.windowStart) >= s.cfg.Interval {
+		s.windowStart = now
+		s.total = 0
+		clear(s.counts)
+	}
+	if s.cfg.First > 0 || s.cfg.Thereafter > 0 {
+		key := level.String() + "|" + msg
+		n := s.counts[key] + 1
+		s.counts[key] = n
+		if n > s.cfg.First && (s.cfg.Thereafter <= 0 || (n-s.cfg.First)%s.cfg.Thereafter != 0) {
+			return false, dropReasonSampled
+		}
+	}
+	if s.cfg.Burst > 0 {
+		if s.total >= s.cfg.Burst {
+			return false, dropReasonRateLimited
+		}
+	}
+	s.total++
+	return true, ""
+}
+
+func (s *sampler) dropped(level lg.Level, reason string) {
+	mf := s.cfg.Metrics
+	if mf == nil {
+		mf = mt.GetGlobal()
+	}
+	mf.GetOrCreateCounter(DroppedEventsMetric, mt.MetricTypeTech, mt.MetricClassInstance).
+		Inc(context.Background(), mt.Attr("level", level.String()), mt.Attr("reason", reason))
+}
@@ -0,0 +1,145 @@
+package backend
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	lg "github.com/totvs/go-sdk/log"
+	mt "github.com/totvs/go-sdk/metrics"
+)
+
+// recordingMetrics is a minimal MetricsFacade that records counter increments
+// by name and attributes. Other instruments fall back to the no-op global.
+type recordingMetrics struct {
+	mt.MetricsFacade
+	mu     sync.Mutex
+	counts map[string]int64
+}
+
+func newRecordingMetrics() *recordingMetrics {
+	return &recordingMetrics{MetricsFacade: mt.GetGlobal(), counts: map[string]int64{}}
+}
+
+func (r *recordingMetrics) total(name string, attrs ...string) int64 {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var n int64
+	for k, v := range r.counts {
+		if !strings.HasPrefix(k, name) {
+			continue
+		}
+		match := true
+		for _, a := range attrs {
+			if !strings.Contains(k, a) {
+				match = false
+			}
+		}
+		if match {
+			n += v
+		}
+	}
+	return n
+}
+
+type recordingCounter struct {
+	r    *recordingMetrics
+	name string
+}
+
+func (c recordingCounter) Add(_ context.Context, incr int64, attrs ...mt.Attribute) {
+	key := c.name
+	for _, a := range attrs {
+		key += fmt.Sprintf(" %s=%v", a.Key, a.Value)
+	}
+	c.r.mu.Lock()
+	c.r.counts[key] += incr
+	c.r.mu.Unlock()
+}
+func (c recordingCounter) Inc(ctx context.Context, attrs ...mt.Attribute) { c.Add(ctx, 1, attrs...) }
+
+func (r *recordingMetrics) GetOrCreateCounter(name string, _ mt.MetricType, _ mt.MetricClass, _ ...mt.MetricOption) mt.Counter {
+	return recordingCounter{r: r, name: name}
+}
+
+func countLines(s string) int { return strings.Count(s, "\n") }
+
+func TestSamplingFirstThenEveryNth(t *testing.T) {
+	buf := &bytes.Buffer{}
+	rec := newRecordingMetrics()
+	l := NewLog(buf, lg.DebugLevel, WithSampling(SamplingConfig{Interval: time.Hour, First: 2, Thereafter: 3, Metrics: rec}))
+
+	for i := 0; i < 10; i++ {
+		l.Error(nil).Int("i", i).Msg("reconcile failed")
+	}
+	// logged: 1st, 2nd, then every 3rd after the first two (5th and 8th)
+	if got := countLines(buf.String()); got != 4 {
+		t.Fatalf("expected 4 lines, got %d: %s", got, buf.String())
+	}
+	if got := rec.total(DroppedEventsMetric, "level=error", "reason=sampled"); got != 6 {
+		t.Fatalf("expected 6 sampled drops, got %d", got)
+	}
+
+	// other messages and levels have their own keys
+	l.Warn().Msg("reconcile failed")
+	l.Error(nil).Msg("other")
+	if got := countLines(buf.String()); got != 6 {
+		t.Fatalf("expected independent keys to be logged, got: %s", buf.String())
+	}
+}
+
+func TestSamplingBurstLimitAndReset(t *testing.T) {
+	buf := &bytes.Buffer{}
+	rec := newRecordingMetrics()
+	l := newLogger(buf, lg.DebugLevel, WithSampling(SamplingConfig{Interval: time.Second, Burst: 3, Metrics: rec}))
+
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	l.sampler.now = func() time.Time { return now }
+
+	for i := 0; i < 5; i++ {
+		l.Info().Msgf("event %d", i)
+	}
+	if got := countLines(buf.String()); got != 3 {
+		t.Fatalf("expected burst of 3, got %d", got)
+	}
+	if got := rec.total(DroppedEventsMetric, "reason=rate_limited"); got != 2 {
+		t.Fatalf("expected 2 rate-limited drops, got %d", got)
+	}
+
+	// derived loggers share the same limits
+	l.WithField("k", "v").Info().Msg("derived")
+	if got := countLines(buf.String()); got != 3 {
+		t.Fatalf("expected derived logger to be limited, got %d", got)
+	}
+
+	now = now.Add(time.Second)
+	l.Info().Msg("next window")
+	if !strings.Contains(buf.String(), "next window") {
+		t.Fatalf("expected counters to reset on a new interval, got: %s", buf.String())
+	}
+}
+
+func TestSamplingConcurrentUse(t *testing.T) {
+	rec := newRecordingMetrics()
+	l := NewLog(io.Discard, lg.DebugLevel, WithSampling(SamplingConfig{First: 1, Thereafter: 10, Metrics: rec}))
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				l.Info().Msg("hot loop")
+			}
+		}()
+	}
+	wg.Wait()
+	if rec.total(DroppedEventsMetric) == 0 {
+		t.Fatal("expected dropped events to be counted")
+	}
+}
@@ -0,0 +1,90 @@
+package backend
+
+import (
+	"io"
+
+	"github.com/rs/zerolog"
+	lg "github.com/totvs/go-sdk/log"
+)
+
+// Sink is one destination of a multi-sink logger. Events below Level are not
+// written to it. Format and NoColor override the logger-wide options for this
+// sink only; an empty Format keeps the logger format.
+type Sink struct {
+	Writer  io.Writer
+	Level   lg.Level
+	Format  lg.Format
+	NoColor bool
+}
+
+// sinkWriter is a sink with its writer chain (redaction and format) built.
+type sinkWriter struct {
+	level lg.Level
+	w     io.Writer
+}
+
+// multiWriter fans every JSON line out to the sinks whose level accepts it.
+// It implements zerolog.LevelWriter so the level is known without decoding
+// the line.
+type multiWriter struct {
+	sinks []sinkWriter
+}
+
+func (m *multiWriter) Write(p []byte) (int, error) {
+	return m.WriteLevel(zerolog.NoLevel, p)
+}
+
+// WriteLevel writes p to every accepting sink. A failing sink does not prevent
+// the others from receiving the line; the first error is returned.
+func (m *multiWriter) WriteLevel(level zerolog.Level, p []byte) (int, error) {
+	var firstErr error
+	for _, s := range m.sinks {
+		if !sinkAccepts(s.level, level) {
+			continue
+		}
+		if _, err := s.w.Write(p); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	if firstErr != nil {
+		return 0, firstErr
+	}
+	return len(p), nil
+}
+
+// sinkAccepts reports whether a line at the zerolog level passes the sink
+// level. The facade levels share zerolog's numbering (Trace=-1 … Panic=5);
+// lines without a level are always written.
+func sinkAccepts(min lg.Level, level zerolog.Level) bool {
+	if level == zerolog.NoLevel || level == zerolog.Disabled {
+		return true
+	}
+	return lg.Level(level) >= min
+}
+
+// NewMultiLog cria um LoggerFacade que replica cada evento para vários
+// destinos, cada um com nível e formato próprios. O nível do logger é o menor
+// nível entre os sinks (ou o AtomicLevel informado via WithAtomicLevel); as
+// demais opções (redação, amostragem, nomes de campos) valem para todos.
+func NewMultiLog(sinks []Sink, opts ...Option) lg.LoggerFacade {
+	cfg := newConfig(opts)
+	min := lg.PanicLevel
+	mw := &multiWriter{sinks: make([]sinkWriter, 0, len(sinks))}
+	for _, s := range sinks {
+		if s.Writer == nil {
+			continue
+		}
+		sc := cfg
+		if s.Format != "" {
+			sc.format = s.Format
+		}
+		if s.NoColor {
+			sc.noColor = true
+		}
+		mw.sinks = append(mw.sinks, sinkWriter{level: s.Level, w: newOutput(s.Writer, sc)})
+		if s.Level < min {
+			min = s.Level
+		}
+	}
+	return buildLogger(mw, min, cfg)
+}
@@ -2,6 +2,7 @@ package backend
 
 import (
 	"context"
+	"fmt"
 	"io"
 	"os"
 	"time"
@@ -12,12 +13,15 @@ import (
 )
 
 // zerolog-backed implementation of the fluent Event interface declared in lg.
+// level and sampler are used to apply sampling once the message is known.
+// sub marks the nested events created by Dict, which must never be written.
 type zerologEvent struct {
-	e *zerolog.Event
+	e       *zerolog.Event
+	level   lg.Level
+	sampler *sampler
+	sub     bool
 }
 
-func newZerologEvent(e *zerolog.Event) lg.LogEvent { return &zerologEvent{e: e} }
-
 func (z *zerologEvent) Str(k, v string) lg.LogEvent             { z.e = z.e.Str(k, v); return z }
 func (z *zerologEvent) Int(k string, v int) lg.LogEvent         { z.e = z.e.Int(k, v); return z }
 func (z *zerologEvent) Int64(k string, v int64) lg.LogEvent     { z.e = z.e.Int64(k, v); return z }
@@ -30,10 +34,46 @@ func (z *zerologEvent) Interface(k string, v interface{}) lg.LogEvent {
 	z.e = z.e.Interface(k, v)
 	return z
 }
+func (z *zerologEvent) Dur(k string, v time.Duration) lg.LogEvent { z.e = z.e.Dur(k, v); return z }
+func (z *zerologEvent) Time(k string, v time.Time) lg.LogEvent    { z.e = z.e.Time(k, v); return z }
+func (z *zerologEvent) Strs(k string, v []string) lg.LogEvent     { z.e = z.e.Strs(k, v); return z }
+func (z *zerologEvent) Ints(k string, v []int) lg.LogEvent        { z.e = z.e.Ints(k, v); return z }
+func (z *zerologEvent) Hex(k string, v []byte) lg.LogEvent        { z.e = z.e.Hex(k, v); return z }
+func (z *zerologEvent) Bytes(k string, v []byte) lg.LogEvent      { z.e = z.e.Bytes(k, v); return z }
+func (z *zerologEvent) Stringer(k string, v fmt.Stringer) lg.LogEvent {
+	z.e = z.e.Stringer(k, v)
+	return z
+}
+func (z *zerologEvent) Dict(k string, fn func(d lg.LogEvent)) lg.LogEvent {
+	if z.e == nil || fn == nil {
+		return z
+	}
+	d := &zerologEvent{e: zerolog.Dict(), sub: true}
+	fn(d)
+	z.e = z.e.Dict(k, d.e)
+	return z
+}
+func (z *zerologEvent) Fields(fields map[string]interface{}) lg.LogEvent {
+	z.e = z.e.Fields(fields)
+	return z
+}
 func (z *zerologEvent) Err(err error) lg.LogEvent { z.e = z.e.Err(err); return z }
-func (z *zerologEvent) Msg(msg string)            { z.e.Msg(msg) }
+func (z *zerologEvent) Msg(msg string) {
+	if z.sub {
+		return
+	}
+	if z.e != nil && z.sampler.allow(z.level, msg) {
+		z.e.Msg(msg)
+	}
+}
 func (z *zerologEvent) Msgf(format string, args ...interface{}) {
-	z.e.Msgf(format, args...)
+	// sample by format string so formatted variants share the same key.
+	if z.sub {
+		return
+	}
+	if z.e != nil && z.sampler.allow(z.level, format) {
+		z.e.Msgf(format, args...)
+	}
 }
 func (z *zerologEvent) Write(p []byte) (n int, err error) {
 	n = len(p)
@@ -41,43 +81,91 @@ func (z *zerologEvent) Write(p []byte) (n int, err error) {
 		// Trim CR added by stdlog.
 		p = p[0 : n-1]
 	}
-	z.e.CallerSkipFrame(1).Msg(string(p))
+	if z.sub {
+		return n, nil
+	}
+	if z.e != nil && z.sampler.allow(z.level, string(p)) {
+		z.e.CallerSkipFrame(1).Msg(string(p))
+	}
 
 	return n, nil
 }
 
-// implLogger is the concrete logger implementation based on zerolog.
-type implLogger struct{ l zerolog.Logger }
+// implLogger is the concrete logger implementation based on zerolog. The
+// level is kept in a shared AtomicLevel so every derived logger observes
+// runtime changes; name selects the per-logger override (see lg.LoggerNameField).
+type implLogger struct {
+	l          zerolog.Logger
+	level      *lg.AtomicLevel
+	name       string
+	sampler    *sampler
+	stack      bool
+	errorChain bool
+}
+
+// newLogger creates a logger that writes to the provided writer using the given
+// level. Output is JSON unless another format is selected through opts. When
+// an AtomicLevel is supplied through opts it becomes the source of truth and
+// level is ignored.
+func newLogger(w io.Writer, level lg.Level, opts ...Option) implLogger {
+	cfg := newConfig(opts)
+	return buildLogger(newOutput(w, cfg), level, cfg)
+}
+
+// newOutput builds the writer chain for w: redaction (when configured), then
+// the output format.
+func newOutput(w io.Writer, cfg config) io.Writer {
+	out := newFormatWriter(w, cfg)
+	if cfg.redaction != nil {
+		out = newRedactWriter(out, *cfg.redaction)
+	}
+	return out
+}
 
-// newLogger creates a logger that writes JSON to the provided writer and uses the given level.
-func newLogger(w io.Writer, level lg.Level) implLogger {
+// buildLogger creates the zerolog logger on top of an already built output.
+func buildLogger(out io.Writer, level lg.Level, cfg config) implLogger {
 	zerolog.TimeFieldFormat = time.RFC3339
-	var zlvl zerolog.Level
-	switch level {
-	case lg.DebugLevel:
-		zlvl = zerolog.DebugLevel
-	case lg.InfoLevel:
-		zlvl = zerolog.InfoLevel
-	case lg.WarnLevel:
-		zlvl = zerolog.WarnLevel
-	case lg.ErrorLevel:
-		zlvl = zerolog.ErrorLevel
-	default:
-		zlvl = zerolog.InfoLevel
-	}
-	lgz := zerolog.New(w).With().Timestamp().Logger().Level(zlvl)
-	return implLogger{l: lgz}
+	atomicLevel := cfg.level
+	if atomicLevel == nil {
+		atomicLevel = lg.NewAtomicLevel(level)
+	}
+	// filtering happens in enabled(); zerolog itself lets everything through.
+	zc := zerolog.New(out).With().Timestamp()
+	if cfg.caller {
+		// one extra frame for zerologEvent.Msg/Msgf.
+		zc = zc.CallerWithSkipFrameCount(zerolog.CallerSkipFrameCount + 1)
+	}
+	lgz := zc.Logger().Level(zerolog.TraceLevel)
+	var smp *sampler
+	if cfg.sampling != nil {
+		smp = newSampler(*cfg.sampling)
+	}
+	return implLogger{l: lgz, level: atomicLevel, sampler: smp, stack: cfg.stack, errorChain: cfg.errorChain}
+}
+
+// derive returns a logger sharing the level holder, sampler, name and error
+// options of l.
+func (l implLogger) derive(zl zerolog.Logger, name string) implLogger {
+	return implLogger{l: zl, level: l.level, name: name, sampler: l.sampler, stack: l.stack, errorChain: l.errorChain}
 }
 
 func (l implLogger) WithField(k string, v interface{}) lg.LoggerFacade {
-	return implLogger{l: l.l.With().Interface(k, v).Logger()}
+	name := l.name
+	if s, ok := v.(string); ok && k == lg.LoggerNameField {
+		name = s
+	}
+	return l.derive(l.l.With().Interface(k, v).Logger(), name)
 }
 
 func (l implLogger) WithFields(fields map[string]interface{}) lg.LoggerFacade {
+	name := l.name
 	c := l.l.With()
 	for k, v := range fields {
 		switch val := v.(type) {
 		case string:
+			if k == lg.LoggerNameField {
+				name = val
+			}
 			c = c.Str(k, val)
 		case int:
 			c = c.Int(k, val)
@@ -97,46 +185,99 @@ func (l implLogger) WithFields(fields map[string]interface{}) lg.LoggerFacade {
 			c = c.Interface(k, val)
 		}
 	}
-	return implLogger{l: c.Logger()}
+	return l.derive(c.Logger(), name)
 }
 
 func (l implLogger) WithTraceFromContext(ctx context.Context) lg.LoggerFacade {
-	if tid := trace.TraceIDFromContext(ctx); tid != "" {
-		return implLogger{l: l.l.With().Str(trace.TraceIDField, tid).Logger()}
+	tid := trace.TraceIDFromContext(ctx)
+	if tid == "" {
+		return l
+	}
+	c := l.l.With().Str(trace.TraceIDField, tid)
+	if sid := trace.SpanIDFromContext(ctx); sid != "" {
+		c = c.Str(trace.SpanIDField, sid)
+	}
+	return l.derive(c.Logger(), l.name)
+}
+
+// Enabled reports whether events at level are emitted by this logger.
+func (l implLogger) Enabled(level lg.Level) bool { return l.level.Enabled(l.name, level) }
+
+// event returns the event for level, wrapping a disabled (nil) zerolog event
+// when the level is filtered out. zerolog treats nil events as no-ops.
+func (l implLogger) event(level lg.Level, ev func() *zerolog.Event) *zerologEvent {
+	if !l.Enabled(level) {
+		return &zerologEvent{level: level}
 	}
-	return l
+	return &zerologEvent{e: ev(), level: level, sampler: l.sampler}
+}
+
+// errorEvent is like event but attaches err when not nil.
+func (l implLogger) errorEvent(level lg.Level, err error, ev func() *zerolog.Event) *zerologEvent {
+	z := l.event(level, ev)
+	l.attachError(z, err, 2)
+	return z
 }
 
-func (l implLogger) Debug() lg.LogEvent { return newZerologEvent(l.l.Debug()) }
-func (l implLogger) Info() lg.LogEvent  { return newZerologEvent(l.l.Info()) }
-func (l implLogger) Warn() lg.LogEvent  { return newZerologEvent(l.l.Warn()) }
-func (l implLogger) Error(err error) lg.LogEvent {
-	ev := l.l.Error()
+// attachError adds err and, when enabled, its chain and the stack trace to z.
+// skip is the number of frames between the user call and attachError.
+func (l implLogger) attachError(z *zerologEvent, err error, skip int) {
+	if z.e == nil {
+		return
+	}
 	if err != nil {
-		ev = ev.Err(err)
+		z.e = z.e.Err(err)
+		if l.errorChain {
+			z.e = z.e.Object(lg.ErrorChainField, errorNode{err: err})
+		}
 	}
-	return newZerologEvent(ev)
+	if l.stack {
+		z.e = z.e.Str(zerolog.ErrorStackFieldName, captureStack(skip+1))
+	}
+}
+
+func (l implLogger) Trace() lg.LogEvent          { return l.event(lg.TraceLevel, l.l.Trace) }
+func (l implLogger) Debug() lg.LogEvent          { return l.event(lg.DebugLevel, l.l.Debug) }
+func (l implLogger) Info() lg.LogEvent           { return l.event(lg.InfoLevel, l.l.Info) }
+func (l implLogger) Warn() lg.LogEvent           { return l.event(lg.WarnLevel, l.l.Warn) }
+func (l implLogger) Error(err error) lg.LogEvent { return l.errorEvent(lg.ErrorLevel, err, l.l.Error) }
+
+// Fatal and Panic are never filtered: the process must terminate (or panic)
+// even when the configured level would hide the message.
+func (l implLogger) Fatal(err error) lg.LogEvent {
+	z := &zerologEvent{e: l.l.Fatal(), level: lg.FatalLevel}
+	l.attachError(z, err, 1)
+	return z
+}
+
+func (l implLogger) Panic(err error) lg.LogEvent {
+	z := &zerologEvent{e: l.l.Panic(), level: lg.PanicLevel}
+	l.attachError(z, err, 1)
+	return z
 }
 
+// SetLevel changes the base level of the shared holder.
+func (l implLogger) SetLevel(level lg.Level) { l.level.SetLevel(level) }
+
+// GetLevel returns the effective level for this logger name.
+func (l implLogger) GetLevel() lg.Level { return l.level.LevelFor(l.name) }
+
+// AtomicLevel exposes the shared level holder (for example to mount
+// util.NewLevelHandler).
+func (l implLogger) AtomicLevel() *lg.AtomicLevel { return l.level }
+
 // NewLog cria um LoggerFacade baseado em zerolog que escreve em `w` com o nível informado.
-func NewLog(w io.Writer, level lg.Level) lg.LoggerFacade {
-	return newLogger(w, level)
+// Opções permitem escolher o formato de saída (JSON por padrão).
+func NewLog(w io.Writer, level lg.Level, opts ...Option) lg.LoggerFacade {
+	return newLogger(w, level, opts...)
 }
 
-// NewDefaultLog cria um adapter zerolog com configurações padrão (stdout, LOG_LEVEL).
-func NewDefaultLog() lg.LoggerFacade {
+// NewDefaultLog cria um adapter zerolog com configurações padrão (stdout, LOG_LEVEL,
+// LOG_FORMAT). Opções explícitas têm precedência sobre as variáveis de ambiente.
+func NewDefaultLog(opts ...Option) lg.LoggerFacade {
 	lvl := lg.InfoLevel
-	if s := os.Getenv("LOG_LEVEL"); s != "" {
-		switch s {
-		case "DEBUG", "debug":
-			lvl = lg.DebugLevel
-		case "INFO", "info":
-			lvl = lg.InfoLevel
-		case "WARN", "warn", "WARNING", "warning":
-			lvl = lg.WarnLevel
-		case "ERROR", "error":
-			lvl = lg.ErrorLevel
-		}
+	if l, ok := lg.ParseLevel(os.Getenv("LOG_LEVEL")); ok {
+		lvl = l
 	}
-	return newLogger(os.Stdout, lvl)
+	return newLogger(os.Stdout, lvl, append(envOptions(), opts...)...)
 }
@@ -0,0 +1,49 @@
+package backend
+
+import (
+	"io"
+	"testing"
+	"time"
+
+	lg "github.com/totvs/go-sdk/log"
+)
+
+// The typed helpers avoid the encoding/json reflection (and most of the
+// allocations) done by Interface; compare with -benchmem.
+
+func BenchmarkEventTypedFields(b *testing.B) {
+	l := NewLog(io.Discard, lg.InfoLevel)
+	tags := []string{"a", "b"}
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		l.Info().
+			Dur("elapsed", time.Second).
+			Strs("tags", tags).
+			Dict("http", func(d lg.LogEvent) { d.Str("method", "GET").Int("status", 200) }).
+			Msg("typed")
+	}
+}
+
+func BenchmarkEventInterfaceFields(b *testing.B) {
+	l := NewLog(io.Discard, lg.InfoLevel)
+	tags := []string{"a", "b"}
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		l.Info().
+			Interface("elapsed", time.Second).
+			Interface("tags", tags).
+			Interface("http", map[string]interface{}{"method": "GET", "status": 200}).
+			Msg("interface")
+	}
+}
+
+func BenchmarkEventDisabled(b *testing.B) {
+	l := NewLog(io.Discard, lg.ErrorLevel)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		l.Debug().Dur("elapsed", time.Second).Strs("tags", nil).Msg("disabled")
+	}
+}
@@ -0,0 +1,148 @@
+package log
+
+import (
+	"strings"
+	"sync"
+	"sync/atomic"
+)
+
+// LoggerNameField is the field that carries the logger name (for example the
+// name assigned by logr's WithName). Loggers derived with this field are
+// subject to the per-name overrides configured in AtomicLevel.
+const LoggerNameField = "logger"
+
+// String returns the lower-case name of the level.
+func (l Level) String() string {
+	switch l {
+	case TraceLevel:
+		return "trace"
+	case DebugLevel:
+		return "debug"
+	case InfoLevel:
+		return "info"
+	case WarnLevel:
+		return "warn"
+	case ErrorLevel:
+		return "error"
+	case FatalLevel:
+		return "fatal"
+	case PanicLevel:
+		return "panic"
+	}
+	return "unknown"
+}
+
+// ParseLevel converts a textual level (case-insensitive) into a Level. The
+// boolean is false when the value is not recognized.
+func ParseLevel(s string) (Level, bool) {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "trace":
+		return TraceLevel, true
+	case "debug":
+		return DebugLevel, true
+	case "info":
+		return InfoLevel, true
+	case "warn", "warning":
+		return WarnLevel, true
+	case "error":
+		return ErrorLevel, true
+	case "fatal":
+		return FatalLevel, true
+	case "panic":
+		return PanicLevel, true
+	}
+	return InfoLevel, false
+}
+
+// AtomicLevel is a concurrency-safe level holder. Loggers created from the
+// same AtomicLevel (and every logger derived from them via WithField,
+// WithFields or WithTraceFromContext) observe level changes immediately, so
+// the level can be adjusted at runtime without rebuilding loggers.
+//
+// Besides the base level, AtomicLevel keeps per-logger-name overrides. A name
+// override also applies to child names separated by "/" (an override for
+// "controller" applies to "controller/reconciler" unless that name has its
+// own override).
+type AtomicLevel struct {
+	level atomic.Int32
+
+	mu        sync.Mutex                       // serializes writers of overrides
+	overrides atomic.Pointer[map[string]Level] // copy-on-write, read without locks
+}
+
+// NewAtomicLevel returns an AtomicLevel initialized with the given level.
+func NewAtomicLevel(l Level) *AtomicLevel {
+	a := &AtomicLevel{}
+	a.level.Store(int32(l))
+	return a
+}
+
+// Level returns the base level.
+func (a *AtomicLevel) Level() Level { return Level(a.level.Load()) }
+
+// SetLevel changes the base level.
+func (a *AtomicLevel) SetLevel(l Level) { a.level.Store(int32(l)) }
+
+// SetNamedLevel overrides the level for loggers with the given name.
+func (a *AtomicLevel) SetNamedLevel(name string, l Level) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	next := a.copyOverrides()
+	next[name] = l
+	a.overrides.Store(&next)
+}
+
+// ClearNamedLevel removes the override for the given name.
+func (a *AtomicLevel) ClearNamedLevel(name string) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	next := a.copyOverrides()
+	delete(next, name)
+	a.overrides.Store(&next)
+}
+
+// NamedLevels returns a copy of the configured per-name overrides.
+func (a *AtomicLevel) NamedLevels() map[string]Level { return a.copyOverrides() }
+
+// LevelFor returns the effective level for a logger name, taking overrides
+// into account. An empty name returns the base level.
+func (a *AtomicLevel) LevelFor(name string) Level {
+	if p := a.overrides.Load(); p != nil && name != "" && len(*p) > 0 {
+		for n := name; n != ""; {
+			if l, ok := (*p)[n]; ok {
+				return l
+			}
+			i := strings.LastIndexByte(n, '/')
+			if i < 0 {
+				break
+			}
+			n = n[:i]
+		}
+	}
+	return a.Level()
+}
+
+// Enabled reports whether a message at level l is emitted by a logger named name.
+func (a *AtomicLevel) Enabled(name string, l Level) bool { return l >= a.LevelFor(name) }
+
+func (a *AtomicLevel) copyOverrides() map[string]Level {
+	out := map[string]Level{}
+	if p := a.overrides.Load(); p != nil {
+		for k, v := range *p {
+			out[k] = v
+		}
+	}
+	return out
+}
+
+// AtomicLevelOf returns the AtomicLevel shared by l when its implementation
+// exposes one (the zerolog backend does). It lets callers wire runtime level
+// controls, such as util.NewLevelHandler, to an existing logger.
+func AtomicLevelOf(l LoggerFacade) (*AtomicLevel, bool) {
+	h, ok := l.(interface{ AtomicLevel() *AtomicLevel })
+	if !ok {
+		return nil, false
+	}
+	a := h.AtomicLevel()
+	return a, a != nil
+}
@@ -0,0 +1,160 @@
+package log_test
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"os/exec"
+	"strings"
+	"sync"
+	"testing"
+
+	logger "github.com/totvs/go-sdk/log"
+	adapter "github.com/totvs/go-sdk/log/adapter"
+)
+
+func TestSetLevelAffectsDerivedLoggers(t *testing.T) {
+	buf := &bytes.Buffer{}
+	base := adapter.NewLog(buf, logger.InfoLevel)
+	child := base.WithField("service", "orders").WithFields(map[string]interface{}{"version": 3})
+
+	child.Debug().Msg("hidden")
+	if strings.Contains(buf.String(), "hidden") {
+		t.Fatalf("expected debug to be filtered, got: %s", buf.String())
+	}
+
+	// changing the level on the parent must affect loggers derived before the change
+	base.SetLevel(logger.DebugLevel)
+	child.Debug().Msg("visible")
+	if !strings.Contains(buf.String(), "visible") {
+		t.Fatalf("expected debug after SetLevel, got: %s", buf.String())
+	}
+	if child.GetLevel() != logger.DebugLevel {
+		t.Fatalf("expected child level debug, got: %s", child.GetLevel())
+	}
+
+	buf.Reset()
+	child.SetLevel(logger.ErrorLevel)
+	base.Warn().Msg("dropped")
+	if buf.Len() != 0 {
+		t.Fatalf("expected warn to be filtered, got: %s", buf.String())
+	}
+}
+
+func TestAtomicLevelNamedOverrides(t *testing.T) {
+	buf := &bytes.Buffer{}
+	lvl := logger.NewAtomicLevel(logger.InfoLevel)
+	base := adapter.NewLog(buf, logger.ErrorLevel, adapter.WithAtomicLevel(lvl))
+	ctrl := base.WithField(logger.LoggerNameField, "controller")
+	rec := base.WithFields(map[string]interface{}{logger.LoggerNameField: "controller/reconciler"})
+
+	lvl.SetNamedLevel("controller", logger.DebugLevel)
+
+	base.Debug().Msg("base-debug")
+	ctrl.Debug().Msg("ctrl-debug")
+	rec.Debug().Msg("rec-debug")
+
+	out := buf.String()
+	if strings.Contains(out, "base-debug") {
+		t.Fatalf("expected base debug to be filtered, got: %s", out)
+	}
+	if !strings.Contains(out, "ctrl-debug") || !strings.Contains(out, "rec-debug") {
+		t.Fatalf("expected named override to enable debug, got: %s", out)
+	}
+
+	lvl.ClearNamedLevel("controller")
+	buf.Reset()
+	ctrl.Debug().Msg("ctrl-debug")
+	if buf.Len() != 0 {
+		t.Fatalf("expected override removal to restore base level, got: %s", buf.String())
+	}
+	if got, ok := logger.AtomicLevelOf(ctrl); !ok || got != lvl {
+		t.Fatalf("expected AtomicLevelOf to return the shared holder")
+	}
+}
+
+func TestAtomicLevelConcurrentAccess(t *testing.T) {
+	lvl := logger.NewAtomicLevel(logger.InfoLevel)
+	f := adapter.NewLog(&bytes.Buffer{}, logger.InfoLevel, adapter.WithAtomicLevel(lvl))
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				lvl.SetLevel(logger.Level(j % 4))
+				lvl.SetNamedLevel("n", logger.Level(i%4))
+				_ = f.WithField(logger.LoggerNameField, "n").GetLevel()
+			}
+		}(i)
+	}
+	wg.Wait()
+}
+
+func TestParseLevel(t *testing.T) {
+	cases := map[string]logger.Level{"DEBUG": logger.DebugLevel, "info": logger.InfoLevel, "Warning": logger.WarnLevel, "error": logger.ErrorLevel}
+	for in, want := range cases {
+		got, ok := logger.ParseLevel(in)
+		if !ok || got != want {
+			t.Fatalf("ParseLevel(%q) = %v, %v; want %v", in, got, ok, want)
+		}
+	}
+	if _, ok := logger.ParseLevel("verbose"); ok {
+		t.Fatal("expected unknown level to be rejected")
+	}
+}
+
+func TestTraceLevelAndEnabled(t *testing.T) {
+	buf := &bytes.Buffer{}
+	f := adapter.NewLog(buf, logger.DebugLevel)
+
+	if f.Enabled(logger.TraceLevel) {
+		t.Fatal("expected trace to be disabled at debug level")
+	}
+	if !f.Enabled(logger.DebugLevel) || !f.Enabled(logger.ErrorLevel) {
+		t.Fatal("expected debug and error to be enabled")
+	}
+	f.Trace().Msg("trace-hidden")
+	if buf.Len() != 0 {
+		t.Fatalf("expected trace to be filtered, got: %s", buf.String())
+	}
+
+	f.SetLevel(logger.TraceLevel)
+	f.Trace().Msg("trace-visible")
+	if !strings.Contains(buf.String(), `"level":"trace"`) {
+		t.Fatalf("expected trace event, got: %s", buf.String())
+	}
+}
+
+func TestPanicLogsAndPanics(t *testing.T) {
+	buf := &bytes.Buffer{}
+	f := adapter.NewLog(buf, logger.ErrorLevel)
+
+	defer func() {
+		if r := recover(); r == nil {
+			t.Fatal("expected panic")
+		}
+		if !strings.Contains(buf.String(), `"level":"panic"`) || !strings.Contains(buf.String(), "boom") {
+			t.Fatalf("expected panic event to be logged, got: %s", buf.String())
+		}
+	}()
+	f.Panic(errors.New("boom")).Msg("unrecoverable")
+}
+
+func TestFatalExits(t *testing.T) {
+	if os.Getenv("LOG_TEST_FATAL") == "1" {
+		adapter.NewLog(os.Stdout, logger.PanicLevel).Fatal(errors.New("boom")).Msg("fatal")
+		return
+	}
+	cmd := exec.Command(os.Args[0], "-test.run=^TestFatalExits$")
+	cmd.Env = append(os.Environ(), "LOG_TEST_FATAL=1")
+	out, err := cmd.Output()
+	var exitErr *exec.ExitError
+	if !errors.As(err, &exitErr) || exitErr.ExitCode() != 1 {
+		t.Fatalf("expected exit code 1, got: %v", err)
+	}
+	if !strings.Contains(string(out), `"level":"fatal"`) {
+		t.Fatalf("expected fatal event even above the configured level, got: %s", out)
+	}
+}
@@ -0,0 +1,57 @@
+package log_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	logger "github.com/totvs/go-sdk/log"
+	adapter "github.com/totvs/go-sdk/log/adapter"
+)
+
+func TestLogrVerbosityMapping(t *testing.T) {
+	cases := map[int]logger.Level{0: logger.InfoLevel, 1: logger.DebugLevel, 4: logger.DebugLevel, 5: logger.TraceLevel, 9: logger.TraceLevel}
+	for v, want := range cases {
+		if got := adapter.VerbosityToLevel(v); got != want {
+			t.Fatalf("VerbosityToLevel(%d) = %s; want %s", v, got, want)
+		}
+	}
+
+	buf := &bytes.Buffer{}
+	lr := adapter.NewLogrAdapter(adapter.NewLog(buf, logger.TraceLevel))
+	lr.V(5).Info("deep", "k", "v")
+
+	var m map[string]interface{}
+	if err := json.Unmarshal(buf.Bytes(), &m); err != nil {
+		t.Fatalf("invalid json: %v, raw: %s", err, buf.String())
+	}
+	if m["level"] != "trace" || m["k"] != "v" {
+		t.Fatalf("expected V(5) to be logged at trace, got: %v", m)
+	}
+}
+
+func TestLogrEnabledFollowsFacadeLevel(t *testing.T) {
+	buf := &bytes.Buffer{}
+	lvl := logger.NewAtomicLevel(logger.InfoLevel)
+	lr := adapter.NewLogrAdapter(adapter.NewLog(buf, logger.InfoLevel, adapter.WithAtomicLevel(lvl)))
+
+	if lr.V(1).Enabled() {
+		t.Fatal("expected V(1) to be disabled at info level")
+	}
+	lr.V(1).Info("hidden")
+	if buf.Len() != 0 {
+		t.Fatalf("expected no output, got: %s", buf.String())
+	}
+
+	// per-name overrides apply to loggers created via WithName
+	lvl.SetNamedLevel("controller", logger.DebugLevel)
+	ctrl := lr.WithName("controller")
+	if !ctrl.V(1).Enabled() || ctrl.V(5).Enabled() {
+		t.Fatal("expected V(1) enabled and V(5) disabled for controller")
+	}
+	ctrl.V(1).Info("visible")
+	if !strings.Contains(buf.String(), `"logger":"controller"`) {
+		t.Fatalf("expected named debug output, got: %s", buf.String())
+	}
+}
@@ -0,0 +1,256 @@
+// Package logtest provides an in-memory LoggerFacade that records structured
+// entries so tests can assert on the logs emitted by SDK and application code
+// without parsing JSON output.
+package logtest
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"sync"
+	"time"
+
+	lg "github.com/totvs/go-sdk/log"
+	"github.com/totvs/go-sdk/trace"
+)
+
+// Entry is a recorded log event. Field values keep their Go types (an Int
+// field is an int, a Dict is a map[string]interface{}, ...).
+type Entry struct {
+	Level   lg.Level
+	Message string
+	Fields  map[string]interface{}
+	Err     error
+	Time    time.Time
+}
+
+// Field returns the value of the field k.
+func (e Entry) Field(k string) (interface{}, bool) {
+	v, ok := e.Fields[k]
+	return v, ok
+}
+
+// recorder is the entry store shared by a Logger and its derived loggers.
+type recorder struct {
+	mu      sync.Mutex
+	entries []Entry
+}
+
+// Logger is a LoggerFacade that records every emitted event. Loggers derived
+// with WithField/WithFields/WithTraceFromContext share the same entries and
+// level. Fatal does not exit the process and Panic panics after recording,
+// so both can be asserted in tests.
+type Logger struct {
+	rec    *recorder
+	level  *lg.AtomicLevel
+	fields map[string]interface{}
+	name   string
+}
+
+// New cria um Logger de teste que registra eventos de todos os níveis (Trace
+// em diante). Use SetLevel para simular outros níveis.
+func New() *Logger {
+	return &Logger{rec: &recorder{}, level: lg.NewAtomicLevel(lg.TraceLevel)}
+}
+
+// Entries returns a copy of the recorded entries in emission order.
+func (l *Logger) Entries() []Entry {
+	l.rec.mu.Lock()
+	defer l.rec.mu.Unlock()
+	return append([]Entry(nil), l.rec.entries...)
+}
+
+// Len returns the number of recorded entries.
+func (l *Logger) Len() int {
+	l.rec.mu.Lock()
+	defer l.rec.mu.Unlock()
+	return len(l.rec.entries)
+}
+
+// Last returns the most recent entry.
+func (l *Logger) Last() (Entry, bool) {
+	l.rec.mu.Lock()
+	defer l.rec.mu.Unlock()
+	if len(l.rec.entries) == 0 {
+		return Entry{}, false
+	}
+	return l.rec.entries[len(l.rec.entries)-1], true
+}
+
+// Reset discards the recorded entries.
+func (l *Logger) Reset() {
+	l.rec.mu.Lock()
+	defer l.rec.mu.Unlock()
+	l.rec.entries = nil
+}
+
+// Filter returns the entries matching every criterion.
+func (l *Logger) Filter(criteria ...Criterion) []Entry {
+	var out []Entry
+	for _, e := range l.Entries() {
+		if matchAll(e, criteria) {
+			out = append(out, e)
+		}
+	}
+	return out
+}
+
+// FilterLevel returns the entries logged at level.
+func (l *Logger) FilterLevel(level lg.Level) []Entry { return l.Filter(Level(level)) }
+
+// FilterMessage returns the entries whose message is msg.
+func (l *Logger) FilterMessage(msg string) []Entry { return l.Filter(Message(msg)) }
+
+// FilterField returns the entries whose field k equals v.
+func (l *Logger) FilterField(k string, v interface{}) []Entry { return l.Filter(Field(k, v)) }
+
+func (l *Logger) record(e Entry) {
+	l.rec.mu.Lock()
+	defer l.rec.mu.Unlock()
+	l.rec.entries = append(l.rec.entries, e)
+}
+
+func (l *Logger) derive(fields map[string]interface{}) *Logger {
+	merged := make(map[string]interface{}, len(l.fields)+len(fields))
+	for k, v := range l.fields {
+		merged[k] = v
+	}
+	name := l.name
+	for k, v := range fields {
+		merged[k] = v
+		if s, ok := v.(string); ok && k == lg.LoggerNameField {
+			name = s
+		}
+	}
+	return &Logger{rec: l.rec, level: l.level, fields: merged, name: name}
+}
+
+func (l *Logger) WithField(k string, v interface{}) lg.LoggerFacade {
+	return l.derive(map[string]interface{}{k: v})
+}
+
+func (l *Logger) WithFields(fields map[string]interface{}) lg.LoggerFacade {
+	if len(fields) == 0 {
+		return l
+	}
+	return l.derive(fields)
+}
+
+func (l *Logger) WithTraceFromContext(ctx context.Context) lg.LoggerFacade {
+	tid := trace.TraceIDFromContext(ctx)
+	if tid == "" {
+		return l
+	}
+	fields := map[string]interface{}{trace.TraceIDField: tid}
+	if sid := trace.SpanIDFromContext(ctx); sid != "" {
+		fields[trace.SpanIDField] = sid
+	}
+	return l.derive(fields)
+}
+
+func (l *Logger) Enabled(level lg.Level) bool { return l.level.Enabled(l.name, level) }
+
+func (l *Logger) event(level lg.Level, err error) lg.LogEvent {
+	if level < lg.FatalLevel && !l.Enabled(level) {
+		return &event{}
+	}
+	ev := &event{l: l, level: level, fields: make(map[string]interface{}, len(l.fields)), err: err}
+	for k, v := range l.fields {
+		ev.fields[k] = v
+	}
+	return ev
+}
+
+func (l *Logger) Trace() lg.LogEvent          { return l.event(lg.TraceLevel, nil) }
+func (l *Logger) Debug() lg.LogEvent          { return l.event(lg.DebugLevel, nil) }
+func (l *Logger) Info() lg.LogEvent           { return l.event(lg.InfoLevel, nil) }
+func (l *Logger) Warn() lg.LogEvent           { return l.event(lg.WarnLevel, nil) }
+func (l *Logger) Error(err error) lg.LogEvent { return l.event(lg.ErrorLevel, err) }
+func (l *Logger) Fatal(err error) lg.LogEvent { return l.event(lg.FatalLevel, err) }
+func (l *Logger) Panic(err error) lg.LogEvent { return l.event(lg.PanicLevel, err) }
+
+func (l *Logger) SetLevel(level lg.Level)      { l.level.SetLevel(level) }
+func (l *Logger) GetLevel() lg.Level           { return l.level.LevelFor(l.name) }
+func (l *Logger) AtomicLevel() *lg.AtomicLevel { return l.level }
+
+// event collects fields until Msg records the entry. A nil logger marks a
+// disabled event; sub marks the nested events created by Dict.
+type event struct {
+	l      *Logger
+	level  lg.Level
+	fields map[string]interface{}
+	err    error
+	sub    bool
+}
+
+func (e *event) set(k string, v interface{}) lg.LogEvent {
+	if e.fields != nil {
+		e.fields[k] = v
+	}
+	return e
+}
+
+func (e *event) Str(k, v string) lg.LogEvent                   { return e.set(k, v) }
+func (e *event) Int(k string, v int) lg.LogEvent               { return e.set(k, v) }
+func (e *event) Int64(k string, v int64) lg.LogEvent           { return e.set(k, v) }
+func (e *event) Uint(k string, v uint) lg.LogEvent             { return e.set(k, v) }
+func (e *event) Uint64(k string, v uint64) lg.LogEvent         { return e.set(k, v) }
+func (e *event) Bool(k string, v bool) lg.LogEvent             { return e.set(k, v) }
+func (e *event) Float32(k string, v float32) lg.LogEvent       { return e.set(k, v) }
+func (e *event) Float64(k string, v float64) lg.LogEvent       { return e.set(k, v) }
+func (e *event) Interface(k string, v interface{}) lg.LogEvent { return e.set(k, v) }
+func (e *event) Dur(k string, v time.Duration) lg.LogEvent     { return e.set(k, v) }
+func (e *event) Time(k string, v time.Time) lg.LogEvent        { return e.set(k, v) }
+func (e *event) Strs(k string, v []string) lg.LogEvent         { return e.set(k, v) }
+func (e *event) Ints(k string, v []int) lg.LogEvent            { return e.set(k, v) }
+func (e *event) Hex(k string, v []byte) lg.LogEvent            { return e.set(k, hex.EncodeToString(v)) }
+func (e *event) Bytes(k string, v []byte) lg.LogEvent          { return e.set(k, string(v)) }
+func (e *event) Stringer(k string, v fmt.Stringer) lg.LogEvent {
+	if v == nil {
+		return e.set(k, nil)
+	}
+	return e.set(k, v.String())
+}
+func (e *event) Dict(k string, fn func(d lg.LogEvent)) lg.LogEvent {
+	if e.fields == nil || fn == nil {
+		return e
+	}
+	d := &event{fields: map[string]interface{}{}, sub: true}
+	fn(d)
+	return e.set(k, d.fields)
+}
+func (e *event) Fields(fields map[string]interface{}) lg.LogEvent {
+	for k, v := range fields {
+		e.set(k, v)
+	}
+	return e
+}
+func (e *event) Err(err error) lg.LogEvent {
+	if err != nil && e.fields != nil {
+		e.err = err
+	}
+	return e
+}
+
+func (e *event) Msg(msg string) { e.msg(msg) }
+
+func (e *event) Msgf(format string, args ...interface{}) { e.msg(fmt.Sprintf(format, args...)) }
+
+func (e *event) Write(p []byte) (int, error) {
+	n := len(p)
+	if n > 0 && p[n-1] == '\n' {
+		p = p[:n-1]
+	}
+	e.msg(string(p))
+	return n, nil
+}
+
+func (e *event) msg(msg string) {
+	if e.sub || e.l == nil {
+		return
+	}
+	e.l.record(Entry{Level: e.level, Message: msg, Fields: e.fields, Err: e.err, Time: time.Now()})
+	if e.level == lg.PanicLevel {
+		panic(msg)
+	}
+}
@@ -0,0 +1,83 @@
+package logtest_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"github.com/stretchr/testify/assert"
+	lg "github.com/totvs/go-sdk/log"
+	"github.com/totvs/go-sdk/log/logtest"
+	"github.com/totvs/go-sdk/trace"
+)
+
+var errNotFound = errors.New("not found")
+
+func TestLoggerRecordsEntries(t *testing.T) {
+	rec := logtest.New()
+	ctx := trace.ContextWithTrace(context.Background(), "t-1")
+
+	l := rec.WithTraceFromContext(ctx).WithField("component", "sync")
+	l.Info().Int("items", 3).Dict("http", func(d lg.LogEvent) { d.Str("method", "GET") }).Msg("synced")
+	l.Error(fmt.Errorf("load: %w", errNotFound)).Msgf("failed after %d tries", 2)
+	rec.Debug().Msg("debug")
+
+	assert.Equal(t, 3, rec.Len())
+	e := rec.FilterMessage("synced")[0]
+	assert.Equal(t, lg.InfoLevel, e.Level)
+	assert.Equal(t, 3, e.Fields["items"])
+	assert.Equal(t, "t-1", e.Fields[trace.TraceIDField])
+	assert.Equal(t, map[string]interface{}{"method": "GET"}, e.Fields["http"])
+
+	logtest.AssertLogged(t, rec, logtest.Level(lg.ErrorLevel), logtest.Err(errNotFound),
+		logtest.Message("failed after 2 tries"), logtest.Field("component", "sync"))
+	logtest.AssertNotLogged(t, rec, logtest.Level(lg.WarnLevel))
+
+	last, ok := rec.Last()
+	assert.True(t, ok)
+	assert.Equal(t, "debug", last.Message)
+
+	rec.Reset()
+	assert.Zero(t, rec.Len())
+}
+
+func TestLoggerLevels(t *testing.T) {
+	rec := logtest.New()
+	rec.SetLevel(lg.WarnLevel)
+	rec.Info().Msg("hidden")
+	rec.Fatal(errNotFound).Msg("fatal is recorded without exiting")
+
+	assert.Len(t, rec.FilterLevel(lg.InfoLevel), 0)
+	assert.Len(t, rec.FilterLevel(lg.FatalLevel), 1)
+	assert.Panics(t, func() { rec.Panic(nil).Msg("boom") })
+	logtest.AssertLogged(t, rec, logtest.Level(lg.PanicLevel), logtest.Message("boom"))
+}
+
+func TestGomegaMatcher(t *testing.T) {
+	g := NewWithT(t)
+	rec := logtest.New()
+	rec.Warn().Str("tenant", "acme").Msg("quota almost exhausted")
+
+	g.Expect(rec).To(logtest.HaveLogged(logtest.Level(lg.WarnLevel), logtest.MessageContains("quota")))
+	g.Expect(rec).NotTo(logtest.HaveLogged(logtest.Field("tenant", "other")))
+	g.Expect(rec.Entries()).To(logtest.HaveLogged(logtest.HasField("tenant")))
+
+	_, err := logtest.HaveLogged().Match("not a logger")
+	g.Expect(err).To(HaveOccurred())
+}
+
+// recordingT captures testify failures.
+type recordingT struct{ failed bool }
+
+func (r *recordingT) Errorf(string, ...interface{}) { r.failed = true }
+
+func TestAssertLoggedFailure(t *testing.T) {
+	rec := logtest.New()
+	rec.Info().Msg("hello")
+
+	rt := &recordingT{}
+	assert.False(t, logtest.AssertLogged(rt, rec, logtest.Message("bye")))
+	assert.True(t, rt.failed)
+}
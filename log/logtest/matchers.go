@@ -0,0 +1,167 @@
+package logtest
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/onsi/gomega/types"
+	"github.com/stretchr/testify/assert"
+	lg "github.com/totvs/go-sdk/log"
+)
+
+// Criterion selects entries. Criteria are combined with AND by Filter and by
+// the matchers below.
+type Criterion struct {
+	desc  string
+	match func(Entry) bool
+}
+
+func (c Criterion) String() string { return c.desc }
+
+// Level matches entries logged at level.
+func Level(level lg.Level) Criterion {
+	return Criterion{fmt.Sprintf("level=%s", level), func(e Entry) bool { return e.Level == level }}
+}
+
+// Message matches entries whose message is msg.
+func Message(msg string) Criterion {
+	return Criterion{fmt.Sprintf("message=%q", msg), func(e Entry) bool { return e.Message == msg }}
+}
+
+// MessageContains matches entries whose message contains substr.
+func MessageContains(substr string) Criterion {
+	return Criterion{fmt.Sprintf("message contains %q", substr), func(e Entry) bool {
+		return strings.Contains(e.Message, substr)
+	}}
+}
+
+// Field matches entries whose field k is deeply equal to v. Values keep their
+// Go types, so Int fields must be compared with an int.
+func Field(k string, v interface{}) Criterion {
+	return Criterion{fmt.Sprintf("%s=%v", k, v), func(e Entry) bool {
+		got, ok := e.Fields[k]
+		return ok && reflect.DeepEqual(got, v)
+	}}
+}
+
+// HasField matches entries that carry the field k.
+func HasField(k string) Criterion {
+	return Criterion{fmt.Sprintf("has field %s", k), func(e Entry) bool {
+		_, ok := e.Fields[k]
+		return ok
+	}}
+}
+
+// Err matches entries whose error is target or wraps it (errors.Is).
+func Err(target error) Criterion {
+	return Criterion{fmt.Sprintf("error is %v", target), func(e Entry) bool { return errors.Is(e.Err, target) }}
+}
+
+// ErrContains matches entries whose error message contains substr.
+func ErrContains(substr string) Criterion {
+	return Criterion{fmt.Sprintf("error contains %q", substr), func(e Entry) bool {
+		return e.Err != nil && strings.Contains(e.Err.Error(), substr)
+	}}
+}
+
+func matchAll(e Entry, criteria []Criterion) bool {
+	for _, c := range criteria {
+		if !c.match(e) {
+			return false
+		}
+	}
+	return true
+}
+
+func describe(criteria []Criterion) string {
+	parts := make([]string, len(criteria))
+	for i, c := range criteria {
+		parts[i] = c.desc
+	}
+	return strings.Join(parts, ", ")
+}
+
+func dump(entries []Entry) string {
+	var b strings.Builder
+	for _, e := range entries {
+		fmt.Fprintf(&b, "\n  [%s] %q fields=%v", e.Level, e.Message, e.Fields)
+		if e.Err != nil {
+			fmt.Fprintf(&b, " error=%q", e.Err)
+		}
+	}
+	if b.Len() == 0 {
+		return " (no entries)"
+	}
+	return b.String()
+}
+
+// HaveLogged is a Gomega matcher succeeding when a *Logger (or []Entry)
+// contains at least one entry matching every criterion:
+//
+//	Expect(rec).To(logtest.HaveLogged(logtest.Level(log.ErrorLevel), logtest.MessageContains("failed")))
+func HaveLogged(criteria ...Criterion) types.GomegaMatcher {
+	return &loggedMatcher{criteria: criteria}
+}
+
+type loggedMatcher struct {
+	criteria []Criterion
+}
+
+func entriesOf(actual interface{}) ([]Entry, error) {
+	switch a := actual.(type) {
+	case *Logger:
+		return a.Entries(), nil
+	case []Entry:
+		return a, nil
+	default:
+		return nil, fmt.Errorf("HaveLogged expects a *logtest.Logger or []logtest.Entry, got %T", actual)
+	}
+}
+
+func (m *loggedMatcher) Match(actual interface{}) (bool, error) {
+	entries, err := entriesOf(actual)
+	if err != nil {
+		return false, err
+	}
+	for _, e := range entries {
+		if matchAll(e, m.criteria) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (m *loggedMatcher) FailureMessage(actual interface{}) string {
+	entries, _ := entriesOf(actual)
+	return fmt.Sprintf("Expected an entry with [%s], recorded:%s", describe(m.criteria), dump(entries))
+}
+
+func (m *loggedMatcher) NegatedFailureMessage(actual interface{}) string {
+	entries, _ := entriesOf(actual)
+	return fmt.Sprintf("Expected no entry with [%s], recorded:%s", describe(m.criteria), dump(entries))
+}
+
+// AssertLogged fails t (testify style) unless l recorded an entry matching
+// every criterion.
+func AssertLogged(t assert.TestingT, l *Logger, criteria ...Criterion) bool {
+	if h, ok := t.(interface{ Helper() }); ok {
+		h.Helper()
+	}
+	if len(l.Filter(criteria...)) > 0 {
+		return true
+	}
+	return assert.Fail(t, fmt.Sprintf("expected an entry with [%s], recorded:%s", describe(criteria), dump(l.Entries())))
+}
+
+// AssertNotLogged fails t unless no recorded entry matches every criterion.
+func AssertNotLogged(t assert.TestingT, l *Logger, criteria ...Criterion) bool {
+	if h, ok := t.(interface{ Helper() }); ok {
+		h.Helper()
+	}
+	if matched := l.Filter(criteria...); len(matched) > 0 {
+		return assert.Fail(t, fmt.Sprintf("expected no entry with [%s], found:%s", describe(criteria), dump(matched)))
+	}
+	return true
+}
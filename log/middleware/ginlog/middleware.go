@@ -0,0 +1,113 @@
+package ginlog
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	log "github.com/totvs/go-sdk/log"
+	"github.com/totvs/go-sdk/log/middleware"
+	"github.com/totvs/go-sdk/log/middleware/internal/httplog"
+	tr "github.com/totvs/go-sdk/trace"
+)
+
+// LoggerKey is the gin.Context key holding the request logger.
+const LoggerKey = "go-sdk/log.logger"
+
+// DefaultOptions are the defaults used by MiddlewareWithLogger: the logger is
+// injected, the trace header is set and a completion entry is logged.
+var DefaultOptions = middleware.MiddlewareOptions{InjectLogger: true, AddTraceHeader: true, AccessLog: true}
+
+// MiddlewareWithOptions returns a gin.HandlerFunc equivalent to
+// middleware.HTTPMiddlewareWithOptions. The request logger is stored in the
+// gin.Context (LoggerKey) and in the request context, and, with AccessLog,
+// the completion entry carries the route template (`route`, from
+// c.FullPath()), status, latency, size, client IP, user agent and the
+// handler errors collected in c.Errors (`errors`).
+func MiddlewareWithOptions(base log.LoggerFacade, opts middleware.MiddlewareOptions) gin.HandlerFunc {
+	access := httplog.NewAccessLog(opts.SkipPaths, opts.SkipHeaders, opts.TrustedProxies)
+	return func(c *gin.Context) {
+		start := time.Now()
+		r := c.Request
+		if httplog.InvalidTraceID(r) {
+			httplog.RejectInvalidTraceID(base, c.Writer, r)
+			c.Abort()
+			return
+		}
+		ctx, tid, span := httplog.Context(r, opts.TracerProvider, spanName(c))
+
+		l := log.WithContext(base, ctx).
+			WithFields(map[string]interface{}{"method": r.Method, "path": r.URL.Path})
+
+		skip := access.Skip(r)
+		if opts.LogRequest && !skip {
+			l.Info().Msg("http request received")
+			ctx = tr.ContextWithLogged(ctx)
+		}
+		if opts.AccessLog && !skip {
+			ctx = tr.ContextWithLogged(ctx)
+		}
+		if opts.InjectLogger {
+			ctx = log.ContextWithBoundLogger(ctx, l)
+			c.Set(LoggerKey, l)
+		}
+		if opts.AddTraceHeader && c.Writer.Header().Get(tr.TraceIDHTTPHeader) == "" {
+			c.Header(tr.TraceIDHTTPHeader, tid)
+		}
+		c.Request = r.WithContext(ctx)
+
+		defer func() {
+			if rec := recover(); rec != nil {
+				httplog.EndSpan(span, http.StatusInternalServerError)
+				panic(rec)
+			}
+			httplog.EndSpan(span, c.Writer.Status())
+		}()
+		c.Next()
+
+		if !opts.AccessLog || skip {
+			return
+		}
+		size := int64(c.Writer.Size())
+		if size < 0 {
+			size = 0
+		}
+		ev := access.Completion(l, c.Request, c.Writer.Status(), size, start)
+		if route := c.FullPath(); route != "" {
+			ev = ev.Str("route", route)
+		}
+		if len(c.Errors) > 0 {
+			ev = ev.Strs("errors", c.Errors.Errors())
+		}
+		ev.Msg(httplog.CompletedMessage)
+	}
+}
+
+// spanName follows the OpenTelemetry HTTP convention "{method} {route}",
+// falling back to the method for unmatched routes.
+func spanName(c *gin.Context) string {
+	if route := c.FullPath(); route != "" {
+		return c.Request.Method + " " + route
+	}
+	return c.Request.Method
+}
+
+// MiddlewareWithLogger is a convenience wrapper that uses DefaultOptions.
+func MiddlewareWithLogger(base log.LoggerFacade) gin.HandlerFunc {
+	return MiddlewareWithOptions(base, DefaultOptions)
+}
+
+// GetLogger returns the request logger stored by the middleware, falling back
+// to the request context and then to the global logger.
+func GetLogger(c *gin.Context) log.LoggerFacade {
+	if c == nil {
+		return log.GetGlobal()
+	}
+	if v, ok := c.Get(LoggerKey); ok {
+		if l, ok := v.(log.LoggerFacade); ok {
+			return l
+		}
+	}
+	l, _ := middleware.GetLoggerFromRequest(c.Request)
+	return l
+}
@@ -0,0 +1,34 @@
+// Package ginlog provides Gin middlewares integrated with the log facade.
+// They live apart from log/middleware so net/http users do not depend on Gin.
+package ginlog
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/totvs/go-sdk/log/middleware"
+	"github.com/totvs/go-sdk/log/middleware/internal/recovery"
+)
+
+// Recovery returns a gin.HandlerFunc equivalent to
+// middleware.RecoveryMiddleware: the panic is logged with the request logger
+// and trace id, middleware.PanicsMetric is incremented (with the route
+// template as `path`) and a JSON 500 carrying X-Request-Id is returned.
+func Recovery(opts middleware.RecoveryOptions) gin.HandlerFunc {
+	reporter := recovery.New(opts.Logger, opts.Metrics)
+	return func(c *gin.Context) {
+		defer func() {
+			rec := recover()
+			if rec == nil {
+				return
+			}
+			if recovery.IsAbort(rec) {
+				panic(rec)
+			}
+			tid := reporter.Report(c.Writer, c.Request, rec, c.FullPath())
+			if !c.Writer.Written() {
+				recovery.WriteResponse(c.Writer, tid)
+			}
+			c.Abort()
+		}()
+		c.Next()
+	}
+}
@@ -0,0 +1,175 @@
+// Package httplog holds the request logging logic shared by the net/http and
+// Gin middlewares: trace context extraction, skip lists, client IP resolution
+// and the access-log completion event.
+package httplog
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/netip"
+	"strings"
+	"time"
+
+	log "github.com/totvs/go-sdk/log"
+	tr "github.com/totvs/go-sdk/trace"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	oteltrace "go.opentelemetry.io/otel/trace"
+)
+
+// TracerName is the instrumentation scope of the server spans.
+const TracerName = "github.com/totvs/go-sdk/log/middleware"
+
+// Context returns the request context enriched with the incoming trace (see
+// trace.ExtractHTTPHeaders) and the trace id. When tp is not nil a server
+// span named spanName is started as a child of the incoming trace; the
+// caller ends it with EndSpan.
+func Context(r *http.Request, tp oteltrace.TracerProvider, spanName string) (context.Context, string, oteltrace.Span) {
+	ctx := tr.ExtractHTTPHeaders(r.Context(), r.Header)
+	var span oteltrace.Span
+	if tp != nil {
+		ctx, span = tp.Tracer(TracerName).Start(tr.ContextWithRemoteParent(ctx), spanName,
+			oteltrace.WithSpanKind(oteltrace.SpanKindServer),
+			oteltrace.WithAttributes(
+				attribute.String("http.request.method", r.Method),
+				attribute.String("url.path", r.URL.Path),
+				attribute.String("user_agent.original", r.UserAgent()),
+			))
+	}
+	return ctx, tr.TraceIDFromContext(ctx), span
+}
+
+// InvalidTraceID reports whether r must be rejected because it carries a
+// trace id refused by the trace Validator under the RejectInvalidID policy.
+func InvalidTraceID(r *http.Request) bool {
+	return tr.GetValidator().Policy == tr.RejectInvalidID && tr.ValidateHTTPHeaders(r.Header) != nil
+}
+
+// RejectInvalidTraceID logs the rejection with l and answers 400 with a JSON
+// body.
+func RejectInvalidTraceID(l log.LoggerFacade, w http.ResponseWriter, r *http.Request) {
+	l.Warn().Str("method", r.Method).Str("path", r.URL.Path).Msg("http request rejected: invalid trace id")
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusBadRequest)
+	_, _ = w.Write([]byte(`{"error":"invalid trace id"}` + "\n"))
+}
+
+// EndSpan records the response status on span and ends it; 5xx responses
+// mark the span as failed. A nil span is ignored.
+func EndSpan(span oteltrace.Span, status int) {
+	if span == nil {
+		return
+	}
+	span.SetAttributes(attribute.Int("http.response.status_code", status))
+	if status >= 500 {
+		span.SetStatus(codes.Error, http.StatusText(status))
+	}
+	span.End()
+}
+
+// AccessLog holds the pre-parsed access-log settings.
+type AccessLog struct {
+	skipPaths   []string
+	skipHeaders map[string]string
+	trusted     []netip.Prefix
+}
+
+// NewAccessLog parses the trusted proxies (IPs or CIDRs; invalid entries are
+// ignored) and keeps the skip lists.
+func NewAccessLog(skipPaths []string, skipHeaders map[string]string, trustedProxies []string) *AccessLog {
+	a := &AccessLog{skipPaths: skipPaths, skipHeaders: skipHeaders}
+	for _, p := range trustedProxies {
+		if prefix, err := netip.ParsePrefix(p); err == nil {
+			a.trusted = append(a.trusted, prefix.Masked())
+		} else if addr, err := netip.ParseAddr(p); err == nil {
+			a.trusted = append(a.trusted, netip.PrefixFrom(addr.Unmap(), addr.Unmap().BitLen()))
+		}
+	}
+	return a
+}
+
+// Skip reports whether the request matches the path or header skip lists.
+func (a *AccessLog) Skip(r *http.Request) bool {
+	for _, p := range a.skipPaths {
+		if prefix, ok := strings.CutSuffix(p, "*"); ok {
+			if strings.HasPrefix(r.URL.Path, prefix) {
+				return true
+			}
+		} else if r.URL.Path == p {
+			return true
+		}
+	}
+	for name, value := range a.skipHeaders {
+		if v := r.Header.Get(name); v != "" && strings.HasPrefix(v, value) {
+			return true
+		}
+	}
+	return false
+}
+
+// ClientIP returns the client address. X-Forwarded-For is only honored when
+// the direct peer is a trusted proxy; the list is then walked from the right,
+// skipping trusted proxies, and the first untrusted address is the client.
+func (a *AccessLog) ClientIP(r *http.Request) string {
+	host := r.RemoteAddr
+	if h, _, err := net.SplitHostPort(r.RemoteAddr); err == nil {
+		host = h
+	}
+	if !a.isTrusted(host) {
+		return host
+	}
+	hops := strings.Split(strings.Join(r.Header.Values("X-Forwarded-For"), ","), ",")
+	for i := len(hops) - 1; i >= 0; i-- {
+		hop := strings.TrimSpace(hops[i])
+		if hop == "" {
+			continue
+		}
+		if !a.isTrusted(hop) {
+			return hop
+		}
+		host = hop
+	}
+	return host
+}
+
+func (a *AccessLog) isTrusted(ip string) bool {
+	if len(a.trusted) == 0 {
+		return false
+	}
+	addr, err := netip.ParseAddr(ip)
+	if err != nil {
+		return false
+	}
+	addr = addr.Unmap()
+	for _, p := range a.trusted {
+		if p.Contains(addr) {
+			return true
+		}
+	}
+	return false
+}
+
+// CompletedMessage is the message of the access-log completion event.
+const CompletedMessage = "http request completed"
+
+// Completion returns the completion event with status, latency, size, client
+// IP and user agent, escalating the level by status class: 5xx is logged as
+// Error, 4xx as Warn and everything else as Info. The caller adds any extra
+// fields and calls Msg(CompletedMessage).
+func (a *AccessLog) Completion(l log.LoggerFacade, r *http.Request, status int, bytes int64, start time.Time) log.LogEvent {
+	var ev log.LogEvent
+	switch {
+	case status >= 500:
+		ev = l.Error(nil)
+	case status >= 400:
+		ev = l.Warn()
+	default:
+		ev = l.Info()
+	}
+	return ev.Int("status", status).
+		Dur("latency", time.Since(start)).
+		Int64("bytes", bytes).
+		Str("client_ip", a.ClientIP(r)).
+		Str("user_agent", r.UserAgent())
+}
@@ -0,0 +1,93 @@
+// Package recovery holds the panic reporting shared by the net/http and Gin
+// recovery middlewares.
+package recovery
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"runtime/debug"
+
+	lg "github.com/totvs/go-sdk/log"
+	mt "github.com/totvs/go-sdk/metrics"
+	tr "github.com/totvs/go-sdk/trace"
+)
+
+// PanicsMetric is the counter incremented for every recovered handler panic.
+const PanicsMetric = "http_panics_total"
+
+// Reporter logs recovered panics and counts them.
+type Reporter struct {
+	logger  lg.LoggerFacade
+	counter mt.Counter
+}
+
+// New creates a Reporter. logger is used when the request carries no
+// request-scoped logger (nil means the global logger) and metrics receives
+// PanicsMetric (nil means metrics.GetGlobal()).
+func New(logger lg.LoggerFacade, metrics mt.MetricsFacade) *Reporter {
+	if metrics == nil {
+		metrics = mt.GetGlobal()
+	}
+	return &Reporter{
+		logger:  logger,
+		counter: metrics.GetOrCreateCounter(PanicsMetric, mt.MetricTypeTech, mt.MetricClassService),
+	}
+}
+
+// Report logs rec with its stack through the request-scoped logger and
+// increments PanicsMetric. route is the route template reported as the path
+// attribute of the metric; it is omitted when empty, since raw paths would
+// create unbounded series. It returns the trace id of the request, reusing
+// the one already sent in the response or carried by the context.
+func (rp *Reporter) Report(w http.ResponseWriter, r *http.Request, rec interface{}, route string) string {
+	stack := debug.Stack()
+	ctx := r.Context()
+	tid := w.Header().Get(tr.TraceIDHTTPHeader)
+	if tid == "" {
+		tid = tr.TraceIDFromContext(ctx)
+	}
+	if tid == "" {
+		tid = tr.GenerateTraceID()
+	}
+
+	l, ok := lg.LoggerFromContext(ctx)
+	if !ok {
+		base := rp.logger
+		if base == nil {
+			base = lg.GetGlobal()
+		}
+		l = base.WithTraceFromContext(tr.ContextWithTrace(ctx, tid)).
+			WithFields(map[string]interface{}{"method": r.Method, "path": r.URL.Path})
+	}
+	err, _ := rec.(error)
+	l.Error(err).Str("panic", fmt.Sprint(rec)).Str("stack", string(stack)).Msg("http handler panic recovered")
+
+	attrs := []mt.Attribute{mt.Attr("method", r.Method)}
+	if route != "" {
+		attrs = append(attrs, mt.Attr("path", route))
+	}
+	rp.counter.Inc(ctx, attrs...)
+	return tid
+}
+
+// IsAbort reports whether rec is http.ErrAbortHandler, which must be
+// re-panicked so net/http aborts the response silently.
+func IsAbort(rec interface{}) bool {
+	err, ok := rec.(error)
+	return ok && errors.Is(err, http.ErrAbortHandler)
+}
+
+// WriteResponse writes the JSON 500 response carrying the trace id in the
+// X-Request-Id header and in the body.
+func WriteResponse(w http.ResponseWriter, traceID string) {
+	h := w.Header()
+	h.Set("Content-Type", "application/json")
+	h.Set(tr.TraceIDHTTPHeader, traceID)
+	w.WriteHeader(http.StatusInternalServerError)
+	_ = json.NewEncoder(w).Encode(map[string]string{
+		"error":      http.StatusText(http.StatusInternalServerError),
+		"request_id": traceID,
+	})
+}
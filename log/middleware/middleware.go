@@ -2,10 +2,13 @@ package middleware
 
 import (
 	"net/http"
+	"time"
 
 	log "github.com/totvs/go-sdk/log"
 	adapter "github.com/totvs/go-sdk/log/adapter"
+	"github.com/totvs/go-sdk/log/middleware/internal/httplog"
 	tr "github.com/totvs/go-sdk/trace"
+	oteltrace "go.opentelemetry.io/otel/trace"
 )
 
 // MiddlewareOptions customizes the behavior of the HTTP middleware.
@@ -16,6 +19,24 @@ type MiddlewareOptions struct {
 	InjectLogger bool
 	// AddTraceHeader controls whether the middleware sets the trace header on the response.
 	AddTraceHeader bool
+	// AccessLog emits an "http request completed" event after the handler
+	// returns, with status, latency, response size, client IP and user agent.
+	// The level is escalated by status class (4xx Warn, 5xx Error).
+	AccessLog bool
+	// SkipPaths lists request paths that are not logged (neither the request
+	// nor the completion event). A trailing "*" matches by prefix.
+	SkipPaths []string
+	// SkipHeaders skips logging for requests carrying any of these headers
+	// whose value starts with the given value (an empty value matches any),
+	// e.g. {"User-Agent": "kube-probe/"}.
+	SkipHeaders map[string]string
+	// TrustedProxies lists the proxy IPs or CIDRs whose X-Forwarded-For header
+	// is honored when resolving the client IP. Invalid entries are ignored.
+	TrustedProxies []string
+	// TracerProvider, when set, starts an OpenTelemetry server span per
+	// request continuing the incoming trace; trace_id/span_id in the logs
+	// then identify that span. Use otel.GetTracerProvider() for the global one.
+	TracerProvider oteltrace.TracerProvider
 }
 
 // DefaultMiddlewareOptions are the defaults used by HTTPMiddlewareWithLogger.
@@ -24,29 +45,28 @@ var DefaultMiddlewareOptions = MiddlewareOptions{LogRequest: true, InjectLogger:
 // HTTPMiddlewareWithOptions returns a middleware using the provided base logger
 // and the supplied options.
 func HTTPMiddlewareWithOptions(base log.LoggerFacade, opts MiddlewareOptions) func(http.Handler) http.Handler {
+	access := httplog.NewAccessLog(opts.SkipPaths, opts.SkipHeaders, opts.TrustedProxies)
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-			tid := r.Header.Get(tr.TraceIDHTTPHeader)
-			if tid == "" {
-				tid = r.Header.Get(tr.TraceIDHTTPCorrelationHeader)
+			start := time.Now()
+			if httplog.InvalidTraceID(r) {
+				httplog.RejectInvalidTraceID(base, w, r)
+				return
 			}
-			if tid == "" {
-				tid = tr.GenerateTraceID()
-			}
-			ctx := tr.ContextWithTrace(r.Context(), tid)
+			ctx, tid, span := httplog.Context(r, opts.TracerProvider, r.Method)
 
-			// prepare a facade that includes trace
-			l := base.WithTraceFromContext(ctx)
-			// method/path are added as structured fields; trace_id is already
-			// added by WithTraceFromContext above so avoid duplicating it here.
+			// prepare a facade carrying the context fields (trace_id, tenant,
+			// ...); method/path are added as structured fields.
+			l := log.WithContext(base, ctx)
 			l2 := l.WithFields(map[string]interface{}{"method": r.Method, "path": r.URL.Path})
 
-			if opts.LogRequest {
+			skip := access.Skip(r)
+			if opts.LogRequest && !skip {
 				l2.Info().Msg("http request received")
 				ctx = tr.ContextWithLogged(ctx)
 			}
 			if opts.InjectLogger {
-				ctx = log.ContextWithLogger(ctx, l2)
+				ctx = log.ContextWithBoundLogger(ctx, l2)
 			}
 			if opts.AddTraceHeader {
 				if w.Header().Get(tr.TraceIDHTTPHeader) == "" {
@@ -54,7 +74,26 @@ func HTTPMiddlewareWithOptions(base log.LoggerFacade, opts MiddlewareOptions) fu
 				}
 			}
 
+			accessLog := opts.AccessLog && !skip
+			if !accessLog && span == nil {
+				next.ServeHTTP(w, r.WithContext(ctx))
+				return
+			}
+			if accessLog {
+				ctx = tr.ContextWithLogged(ctx)
+			}
+			rw, w := newResponseRecorder(w)
+			defer func() {
+				if rec := recover(); rec != nil {
+					httplog.EndSpan(span, http.StatusInternalServerError)
+					panic(rec)
+				}
+				httplog.EndSpan(span, rw.status)
+			}()
 			next.ServeHTTP(w, r.WithContext(ctx))
+			if accessLog {
+				access.Completion(l2, r, rw.status, rw.bytes, start).Msg(httplog.CompletedMessage)
+			}
 		})
 	}
 }
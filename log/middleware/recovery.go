@@ -0,0 +1,54 @@
+package middleware
+
+import (
+	"net/http"
+
+	log "github.com/totvs/go-sdk/log"
+	"github.com/totvs/go-sdk/log/middleware/internal/recovery"
+	mt "github.com/totvs/go-sdk/metrics"
+)
+
+// PanicsMetric is the counter incremented for every recovered handler panic
+// (attributes `method` and `path`, the matched route pattern; `path` is
+// omitted when no pattern matched).
+const PanicsMetric = recovery.PanicsMetric
+
+// RecoveryOptions customizes RecoveryMiddleware.
+type RecoveryOptions struct {
+	// Logger is used when the request has no request-scoped logger (for
+	// example when the recovery middleware runs outside the log middleware).
+	// Defaults to the global logger.
+	Logger log.LoggerFacade
+	// Metrics receives PanicsMetric. Defaults to metrics.GetGlobal().
+	Metrics mt.MetricsFacade
+}
+
+// RecoveryMiddleware returns a middleware that recovers handler panics, logs
+// the panic value and stack with the request logger (including the trace id),
+// increments PanicsMetric and answers with a JSON 500 carrying X-Request-Id.
+// Place it inside HTTPMiddlewareWithOptions so the request logger is available.
+// When the handler already started the response only the log and the metric
+// are produced. http.ErrAbortHandler is re-panicked, as net/http expects.
+func RecoveryMiddleware(opts RecoveryOptions) func(http.Handler) http.Handler {
+	reporter := recovery.New(opts.Logger, opts.Metrics)
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			rw, w := newResponseRecorder(w)
+			defer func() {
+				rec := recover()
+				if rec == nil {
+					return
+				}
+				if recovery.IsAbort(rec) {
+					panic(rec)
+				}
+				// r.Pattern is set by the http.ServeMux wrapped by this middleware.
+				tid := reporter.Report(rw, r, rec, r.Pattern)
+				if !rw.wroteHeader {
+					recovery.WriteResponse(rw, tid)
+				}
+			}()
+			next.ServeHTTP(w, r)
+		})
+	}
+}
@@ -0,0 +1,126 @@
+package middleware
+
+import (
+	"bufio"
+	"io"
+	"net"
+	"net/http"
+)
+
+// responseRecorder wraps an http.ResponseWriter to capture the status code and
+// the number of body bytes written. Unwrap lets http.ResponseController reach
+// the underlying writer.
+type responseRecorder struct {
+	http.ResponseWriter
+	status      int
+	bytes       int64
+	wroteHeader bool
+}
+
+// newResponseRecorder returns the recorder and the writer to hand to the next
+// handler: the recorder extended with exactly the optional interfaces
+// (http.Flusher, http.Hijacker, io.ReaderFrom) that w implements, so handlers
+// probing them with type assertions see the same capabilities as without
+// the middleware.
+func newResponseRecorder(w http.ResponseWriter) (*responseRecorder, http.ResponseWriter) {
+	rw := &responseRecorder{ResponseWriter: w, status: http.StatusOK}
+	_, fl := w.(http.Flusher)
+	_, hj := w.(http.Hijacker)
+	_, rf := w.(io.ReaderFrom)
+	f, h, r := flusher{rw}, hijacker{rw}, readerFrom{rw}
+	switch {
+	case fl && hj && rf:
+		return rw, struct {
+			*responseRecorder
+			flusher
+			hijacker
+			readerFrom
+		}{rw, f, h, r}
+	case fl && hj:
+		return rw, struct {
+			*responseRecorder
+			flusher
+			hijacker
+		}{rw, f, h}
+	case fl && rf:
+		return rw, struct {
+			*responseRecorder
+			flusher
+			readerFrom
+		}{rw, f, r}
+	case hj && rf:
+		return rw, struct {
+			*responseRecorder
+			hijacker
+			readerFrom
+		}{rw, h, r}
+	case fl:
+		return rw, struct {
+			*responseRecorder
+			flusher
+		}{rw, f}
+	case hj:
+		return rw, struct {
+			*responseRecorder
+			hijacker
+		}{rw, h}
+	case rf:
+		return rw, struct {
+			*responseRecorder
+			readerFrom
+		}{rw, r}
+	}
+	return rw, rw
+}
+
+func (rw *responseRecorder) WriteHeader(code int) {
+	if !rw.wroteHeader {
+		rw.status = code
+		// 1xx informational responses may precede the final status.
+		rw.wroteHeader = code >= 200 || code == http.StatusSwitchingProtocols
+	}
+	rw.ResponseWriter.WriteHeader(code)
+}
+
+// commit records the implicit 200 sent by net/http when the response starts
+// without a final WriteHeader (a preceding 1xx code is not the final status).
+func (rw *responseRecorder) commit() {
+	if !rw.wroteHeader {
+		rw.status = http.StatusOK
+		rw.wroteHeader = true
+	}
+}
+
+func (rw *responseRecorder) Write(p []byte) (int, error) {
+	rw.commit()
+	n, err := rw.ResponseWriter.Write(p)
+	rw.bytes += int64(n)
+	return n, err
+}
+
+func (rw *responseRecorder) Unwrap() http.ResponseWriter { return rw.ResponseWriter }
+
+type flusher struct{ rw *responseRecorder }
+
+func (f flusher) Flush() {
+	f.rw.commit()
+	f.rw.ResponseWriter.(http.Flusher).Flush()
+}
+
+type hijacker struct{ rw *responseRecorder }
+
+func (h hijacker) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	h.rw.commit()
+	return h.rw.ResponseWriter.(http.Hijacker).Hijack()
+}
+
+// readerFrom keeps the sendfile/splice fast path of the underlying writer
+// (used by io.Copy and http.ServeContent) while counting the bytes sent.
+type readerFrom struct{ rw *responseRecorder }
+
+func (r readerFrom) ReadFrom(src io.Reader) (int64, error) {
+	r.rw.commit()
+	n, err := r.rw.ResponseWriter.(io.ReaderFrom).ReadFrom(src)
+	r.rw.bytes += n
+	return n, err
+}
@@ -0,0 +1,155 @@
+package middleware
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	log "github.com/totvs/go-sdk/log"
+	"github.com/totvs/go-sdk/log/middleware/internal/httplog"
+	mt "github.com/totvs/go-sdk/metrics"
+	tr "github.com/totvs/go-sdk/trace"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	oteltrace "go.opentelemetry.io/otel/trace"
+)
+
+// Metrics recorded by Transport (attributes `method`, `host` and `status`;
+// status is "error" when no response was received).
+const (
+	ClientRequestsMetric = "http_client_requests_total"
+	ClientDurationMetric = "http_client_request_duration_seconds"
+)
+
+// Messages of the outbound request log entries.
+const (
+	ClientCompletedMessage = "http client request completed"
+	ClientFailedMessage    = "http client request failed"
+)
+
+// TransportOptions customizes Transport.
+type TransportOptions struct {
+	// Logger logs the outbound calls, enriched with the request context
+	// fields. Defaults to log.Ctx(req.Context()).
+	Logger log.LoggerFacade
+	// Metrics receives ClientRequestsMetric and ClientDurationMetric.
+	// Defaults to metrics.GetGlobal().
+	Metrics mt.MetricsFacade
+	// TracerProvider, when set, starts an OpenTelemetry client span per call
+	// and propagates it in traceparent.
+	TracerProvider oteltrace.TracerProvider
+}
+
+// Transport returns an http.RoundTripper that propagates the trace of the
+// request context (X-Request-Id and, for W3C ids, traceparent/tracestate),
+// logs every call (Debug; Warn for 5xx; Error when the call fails) and
+// records count and latency metrics. Headers already set on the request are
+// kept. A nil base uses http.DefaultTransport.
+//
+//	client := &http.Client{Transport: middleware.Transport(nil, middleware.TransportOptions{})}
+func Transport(base http.RoundTripper, opts TransportOptions) http.RoundTripper {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	metrics := opts.Metrics
+	if metrics == nil {
+		metrics = mt.GetGlobal()
+	}
+	return &transport{
+		base:     base,
+		logger:   opts.Logger,
+		tp:       opts.TracerProvider,
+		requests: metrics.GetOrCreateCounter(ClientRequestsMetric, mt.MetricTypeTech, mt.MetricClassService),
+		duration: metrics.GetOrCreateHistogram(ClientDurationMetric, mt.MetricTypeTech, mt.MetricClassService),
+	}
+}
+
+type transport struct {
+	base     http.RoundTripper
+	logger   log.LoggerFacade
+	tp       oteltrace.TracerProvider
+	requests mt.Counter
+	duration mt.Histogram
+}
+
+func (t *transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	start := time.Now()
+	ctx := req.Context()
+	var span oteltrace.Span
+	if t.tp != nil {
+		ctx, span = t.tp.Tracer(httplog.TracerName).Start(tr.ContextWithRemoteParent(ctx), req.Method,
+			oteltrace.WithSpanKind(oteltrace.SpanKindClient),
+			oteltrace.WithAttributes(
+				attribute.String("http.request.method", req.Method),
+				attribute.String("server.address", req.URL.Hostname()),
+				attribute.String("url.full", redactedURL(req)),
+			))
+		defer span.End()
+	}
+
+	// RoundTrippers must not modify the caller's request.
+	out := req.Clone(ctx)
+	headers := http.Header{}
+	tr.InjectHTTPHeaders(ctx, headers)
+	for k, v := range headers {
+		if out.Header.Get(k) == "" {
+			out.Header[k] = v
+		}
+	}
+
+	resp, err := t.base.RoundTrip(out)
+	latency := time.Since(start)
+
+	status := "error"
+	if err == nil {
+		status = strconv.Itoa(resp.StatusCode)
+	}
+	attrs := []mt.Attribute{mt.Attr("method", req.Method), mt.Attr("host", req.URL.Host), mt.Attr("status", status)}
+	t.requests.Inc(ctx, attrs...)
+	t.duration.Record(ctx, latency.Seconds(), attrs...)
+
+	// log with the caller context: its span is the one the request logger
+	// is bound to.
+	l := t.logger
+	if l == nil {
+		l = log.Ctx(req.Context())
+	} else {
+		l = log.WithContext(l, req.Context())
+	}
+	var ev log.LogEvent
+	switch {
+	case err != nil:
+		ev = l.Error(err)
+	case resp.StatusCode >= 500:
+		ev = l.Warn()
+	default:
+		ev = l.Debug()
+	}
+	ev = ev.Str("method", req.Method).Str("url", redactedURL(req)).Dur("latency", latency)
+	if err != nil {
+		ev.Msg(ClientFailedMessage)
+	} else {
+		ev.Int("status", resp.StatusCode).Msg(ClientCompletedMessage)
+	}
+
+	if span != nil {
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		} else {
+			span.SetAttributes(attribute.Int("http.response.status_code", resp.StatusCode))
+			if resp.StatusCode >= 400 {
+				span.SetStatus(codes.Error, http.StatusText(resp.StatusCode))
+			}
+		}
+	}
+	return resp, err
+}
+
+// redactedURL returns the request URL without query, fragment and user
+// info, which may carry credentials.
+func redactedURL(req *http.Request) string {
+	u := *req.URL
+	u.RawQuery, u.ForceQuery, u.Fragment, u.RawFragment, u.User = "", false, "", "", nil
+	return u.String()
+}
@@ -0,0 +1,170 @@
+package log_test
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	logger "github.com/totvs/go-sdk/log"
+	adapter "github.com/totvs/go-sdk/log/adapter"
+)
+
+func TestMultiLogPerSinkLevelAndFormat(t *testing.T) {
+	stdout, file, audit := &bytes.Buffer{}, &bytes.Buffer{}, &bytes.Buffer{}
+	l := adapter.NewMultiLog([]adapter.Sink{
+		{Writer: stdout, Level: logger.InfoLevel},
+		{Writer: file, Level: logger.DebugLevel, Format: logger.FormatLogfmt},
+		{Writer: audit, Level: logger.ErrorLevel},
+	})
+
+	if l.GetLevel() != logger.DebugLevel {
+		t.Fatalf("expected the logger level to be the lowest sink level, got %s", l.GetLevel())
+	}
+	l.Trace().Msg("trace-msg")
+	l.Debug().Msg("debug-msg")
+	l.Info().Msg("info-msg")
+	l.Error(errors.New("boom")).Msg("error-msg")
+
+	if strings.Contains(stdout.String(), "debug-msg") || !strings.Contains(stdout.String(), `"message":"info-msg"`) ||
+		!strings.Contains(stdout.String(), "error-msg") {
+		t.Fatalf("unexpected stdout sink output: %s", stdout.String())
+	}
+	if strings.Count(file.String(), "\n") != 3 || !strings.Contains(file.String(), "msg=debug-msg") {
+		t.Fatalf("expected 3 logfmt lines in the file sink, got: %s", file.String())
+	}
+	if strings.Count(audit.String(), "\n") != 1 || !strings.Contains(audit.String(), `"error":"boom"`) {
+		t.Fatalf("expected only the error in the audit sink, got: %s", audit.String())
+	}
+	if strings.Contains(file.String(), "trace-msg") {
+		t.Fatalf("trace events must be filtered by the logger level, got: %s", file.String())
+	}
+}
+
+func TestMultiLogAppliesRedactionToEverySink(t *testing.T) {
+	a, b := &bytes.Buffer{}, &bytes.Buffer{}
+	l := adapter.NewMultiLog([]adapter.Sink{{Writer: a}, {Writer: b, Format: logger.FormatLogfmt}},
+		adapter.WithRedaction(adapter.DefaultRedactionConfig()))
+	l.Info().Str("password", "hunter2").Msg("login")
+
+	for _, out := range []string{a.String(), b.String()} {
+		if strings.Contains(out, "hunter2") {
+			t.Fatalf("expected the password to be masked, got: %s", out)
+		}
+	}
+}
+
+func TestRotatingFileSizeRotationAndRetention(t *testing.T) {
+	dir := t.TempDir()
+	name := filepath.Join(dir, "logs", "app.log")
+	rf, err := adapter.NewRotatingFile(adapter.RotatingFileOptions{Filename: name, MaxSize: 64, MaxBackups: 2})
+	if err != nil {
+		t.Fatalf("new rotating file: %v", err)
+	}
+	l := adapter.NewLog(rf, logger.InfoLevel)
+	for i := 0; i < 20; i++ {
+		l.Info().Int("i", i).Msg("rotate me")
+	}
+	if err := rf.Close(); err != nil {
+		t.Fatalf("close: %v", err)
+	}
+
+	backups, _ := filepath.Glob(filepath.Join(dir, "logs", "app-*.log"))
+	if len(backups) != 2 {
+		t.Fatalf("expected 2 retained backups, got %v", backups)
+	}
+	data, err := os.ReadFile(name)
+	if err != nil || !strings.Contains(string(data), `"i":19`) {
+		t.Fatalf("expected the last line in the active file, got %q (%v)", data, err)
+	}
+	if _, err := rf.Write([]byte("late\n")); !errors.Is(err, os.ErrClosed) {
+		t.Fatalf("expected os.ErrClosed after Close, got %v", err)
+	}
+}
+
+func TestRotatingFileKeepsWritingWhenRotationFails(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "logs")
+	name := filepath.Join(dir, "app.log")
+	rf, err := adapter.NewRotatingFile(adapter.RotatingFileOptions{Filename: name, MaxSize: 8})
+	if err != nil {
+		t.Fatalf("new rotating file: %v", err)
+	}
+	defer rf.Close()
+	if _, err := rf.Write([]byte("first\n")); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+
+	// The active file cannot be reopened while its directory is missing.
+	if err := os.RemoveAll(dir); err != nil {
+		t.Fatal(err)
+	}
+	if n, err := rf.Write([]byte("second\n")); err == nil || n != len("second\n") {
+		t.Fatalf("expected the line written with the rotation error, got n=%d err=%v", n, err)
+	}
+
+	// Once the directory is back, the next write rotates and logging goes on.
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := rf.Write([]byte("third\n")); err != nil {
+		t.Fatalf("expected the writer to recover, got %v", err)
+	}
+	if data, err := os.ReadFile(name); err != nil || string(data) != "third\n" {
+		t.Fatalf("expected the new active file, got %q (%v)", data, err)
+	}
+}
+
+func TestRotatingFileKeepsNewestBackupOfSameTimestamp(t *testing.T) {
+	dir := t.TempDir()
+	name := filepath.Join(dir, "app.log")
+	// Two earlier rotations within the same millisecond.
+	stamp := time.Now().Add(-time.Minute).Format("20060102T150405.000")
+	older, newer := filepath.Join(dir, "app-"+stamp+".log"), filepath.Join(dir, "app-"+stamp+".1.log")
+	for _, f := range []string{older, newer} {
+		if err := os.WriteFile(f, []byte("backup\n"), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	rf, err := adapter.NewRotatingFile(adapter.RotatingFileOptions{Filename: name, MaxBackups: 2})
+	if err != nil {
+		t.Fatalf("new rotating file: %v", err)
+	}
+	defer rf.Close()
+	_, _ = rf.Write([]byte("current\n"))
+	if err := rf.Rotate(); err != nil {
+		t.Fatalf("rotate: %v", err)
+	}
+
+	if _, err := os.Stat(newer); err != nil {
+		t.Fatalf("expected the newer backup of the same timestamp to be kept: %v", err)
+	}
+	if _, err := os.Stat(older); !os.IsNotExist(err) {
+		t.Fatalf("expected the older backup of the same timestamp to be removed, got %v", err)
+	}
+}
+
+func TestRotatingFileTimeRotation(t *testing.T) {
+	dir := t.TempDir()
+	name := filepath.Join(dir, "audit.log")
+	rf, err := adapter.NewRotatingFile(adapter.RotatingFileOptions{Filename: name, Interval: 10 * time.Millisecond})
+	if err != nil {
+		t.Fatalf("new rotating file: %v", err)
+	}
+	defer rf.Close()
+
+	_, _ = rf.Write([]byte("first\n"))
+	time.Sleep(20 * time.Millisecond)
+	_, _ = rf.Write([]byte("second\n"))
+
+	backups, _ := filepath.Glob(filepath.Join(dir, "audit-*.log"))
+	if len(backups) != 1 {
+		t.Fatalf("expected one time-based rotation, got %v", backups)
+	}
+	if data, _ := os.ReadFile(backups[0]); string(data) != "first\n" {
+		t.Fatalf("expected the first line in the backup, got %q", data)
+	}
+}
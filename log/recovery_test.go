@@ -0,0 +1,122 @@
+package log_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	logger "github.com/totvs/go-sdk/log"
+	adapter "github.com/totvs/go-sdk/log/adapter"
+	middleware "github.com/totvs/go-sdk/log/middleware"
+	"github.com/totvs/go-sdk/log/middleware/ginlog"
+	mt "github.com/totvs/go-sdk/metrics"
+	tr "github.com/totvs/go-sdk/trace"
+)
+
+func assertPanicResponse(t *testing.T, rec *httptest.ResponseRecorder, tid string) {
+	t.Helper()
+	var body map[string]string
+	if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+		t.Fatalf("expected a JSON body: %v, raw: %s", err, rec.Body.String())
+	}
+	if rec.Code != http.StatusInternalServerError || rec.Header().Get(tr.TraceIDHTTPHeader) != tid || body["request_id"] != tid {
+		t.Fatalf("unexpected panic response: %d %v %v", rec.Code, rec.Header(), body)
+	}
+}
+
+func TestRecoveryMiddlewareLogsWithTraceID(t *testing.T) {
+	buf := &bytes.Buffer{}
+	m := &countingMetrics{MetricsFacade: mt.GetGlobal(), name: middleware.PanicsMetric}
+	base := adapter.NewLog(buf, logger.InfoLevel)
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /crash/{id}", func(http.ResponseWriter, *http.Request) { panic(errors.New("boom")) })
+	h := middleware.RecoveryMiddleware(middleware.RecoveryOptions{Metrics: m})(mux)
+	h = middleware.HTTPMiddlewareWithOptions(base, middleware.MiddlewareOptions{InjectLogger: true, AddTraceHeader: true})(h)
+
+	req := httptest.NewRequest(http.MethodGet, "/crash/7", nil)
+	req.Header.Set(tr.TraceIDHTTPHeader, "trace-123")
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+
+	assertPanicResponse(t, rec, "trace-123")
+	line := findLine(buf.String(), "http handler panic recovered")
+	if line == nil {
+		t.Fatalf("expected a panic log line, got: %s", buf.String())
+	}
+	if line[tr.TraceIDField] != "trace-123" || line["error"] != "boom" || line["path"] != "/crash/7" ||
+		!strings.Contains(line["stack"].(string), "recovery_test.go") {
+		t.Fatalf("unexpected panic log: %v", line)
+	}
+	if m.n.Load() != 1 {
+		t.Fatalf("expected the panic counter to be incremented once, got %d", m.n.Load())
+	}
+	// The metric uses the route pattern, never the raw path.
+	if path, _ := m.lastAttr("path"); path != "GET /crash/{id}" {
+		t.Fatalf("expected the route pattern in the metric, got %v", path)
+	}
+}
+
+func TestRecoveryMiddlewareWithoutLogMiddleware(t *testing.T) {
+	buf := &bytes.Buffer{}
+	m := &countingMetrics{MetricsFacade: mt.GetGlobal(), name: middleware.PanicsMetric}
+	h := middleware.RecoveryMiddleware(middleware.RecoveryOptions{Logger: adapter.NewLog(buf, logger.InfoLevel), Metrics: m})(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusAccepted)
+			panic("late")
+		}))
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+
+	if rec.Code != http.StatusAccepted {
+		t.Fatalf("a started response must not be overwritten, got %d", rec.Code)
+	}
+	if line := findLine(buf.String(), "http handler panic recovered"); line["panic"] != "late" || line[tr.TraceIDField] == "" {
+		t.Fatalf("expected the fallback logger with a generated trace id, got %v", line)
+	}
+	if path, ok := m.lastAttr("path"); ok {
+		t.Fatalf("expected no path attribute without a route pattern, got %v", path)
+	}
+}
+
+func TestRecoveryMiddlewareRepanicsAbortHandler(t *testing.T) {
+	h := middleware.RecoveryMiddleware(middleware.RecoveryOptions{})(
+		http.HandlerFunc(func(http.ResponseWriter, *http.Request) { panic(http.ErrAbortHandler) }))
+	defer func() {
+		if rec := recover(); rec != http.ErrAbortHandler {
+			t.Fatalf("expected http.ErrAbortHandler to propagate, got %v", rec)
+		}
+	}()
+	h.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+}
+
+func TestGinRecovery(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	buf := &bytes.Buffer{}
+	m := &countingMetrics{MetricsFacade: mt.GetGlobal(), name: middleware.PanicsMetric}
+
+	r := gin.New()
+	r.Use(ginlog.Recovery(middleware.RecoveryOptions{Logger: adapter.NewLog(buf, logger.InfoLevel), Metrics: m}))
+	r.GET("/items/:id", func(*gin.Context) { panic("nil map") })
+
+	req := httptest.NewRequest(http.MethodGet, "/items/42", nil)
+	req = req.WithContext(tr.ContextWithTrace(req.Context(), "gin-trace"))
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, req)
+
+	assertPanicResponse(t, rec, "gin-trace")
+	if line := findLine(buf.String(), "http handler panic recovered"); line[tr.TraceIDField] != "gin-trace" || line["panic"] != "nil map" {
+		t.Fatalf("unexpected panic log: %v", line)
+	}
+	if m.n.Load() != 1 {
+		t.Fatalf("expected the panic counter to be incremented once, got %d", m.n.Load())
+	}
+	if path, _ := m.lastAttr("path"); path != "/items/:id" {
+		t.Fatalf("expected the Gin route in the metric, got %v", path)
+	}
+}
@@ -0,0 +1,106 @@
+package log_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"log/slog"
+	"strings"
+	"testing"
+
+	logger "github.com/totvs/go-sdk/log"
+	adapter "github.com/totvs/go-sdk/log/adapter"
+	tr "github.com/totvs/go-sdk/trace"
+)
+
+func TestSlogHandlerForwardsToFacade(t *testing.T) {
+	buf := &bytes.Buffer{}
+	sl := slog.New(adapter.NewSlogHandler(adapter.NewLog(buf, logger.InfoLevel)))
+
+	sl.With("service", "orders").WithGroup("req").Info("handled", "method", "GET", slog.Group("client", "ip", "10.0.0.1"), "status", 200)
+
+	m := decodeLine(t, buf.Bytes())
+	if m["level"] != "info" || m["message"] != "handled" || m["service"] != "orders" {
+		t.Fatalf("unexpected event: %v", m)
+	}
+	req, ok := m["req"].(map[string]interface{})
+	if !ok || req["method"] != "GET" || req["status"].(float64) != 200 {
+		t.Fatalf("expected group req to be a nested object, got: %v", m["req"])
+	}
+	if client, ok := req["client"].(map[string]interface{}); !ok || client["ip"] != "10.0.0.1" {
+		t.Fatalf("expected nested group client, got: %v", req["client"])
+	}
+}
+
+func TestSlogHandlerLevelsAndErrors(t *testing.T) {
+	buf := &bytes.Buffer{}
+	sl := slog.New(adapter.NewSlogHandler(adapter.NewLog(buf, logger.InfoLevel)))
+
+	if sl.Enabled(context.Background(), slog.LevelDebug) {
+		t.Fatal("expected debug to be disabled")
+	}
+	sl.Debug("hidden")
+	if buf.Len() != 0 {
+		t.Fatalf("expected no output, got: %s", buf.String())
+	}
+
+	ctx := tr.ContextWithTrace(context.Background(), "trace-slog")
+	sl.ErrorContext(ctx, "failed", "err", errors.New("boom"), "attempt", 3)
+	m := decodeLine(t, buf.Bytes())
+	if m["level"] != "error" || m["error"] != "boom" || m["trace_id"] != "trace-slog" {
+		t.Fatalf("unexpected error event: %v", m)
+	}
+
+	buf.Reset()
+	sl.Warn("careful", "cause", errors.New("disk"))
+	m = decodeLine(t, buf.Bytes())
+	if m["level"] != "warn" || m["cause"] != "disk" {
+		t.Fatalf("expected error attributes rendered as text, got: %v", m)
+	}
+}
+
+func TestSlogBackedFacade(t *testing.T) {
+	buf := &bytes.Buffer{}
+	h := slog.NewJSONHandler(buf, &slog.HandlerOptions{Level: slog.LevelDebug - 4})
+	f := adapter.NewSlogLogger(h, logger.DebugLevel)
+
+	f.WithFields(map[string]interface{}{"service": "orders"}).Info().Str("k", "v").Int("n", 2).Msg("hello")
+	m := decodeLine(t, buf.Bytes())
+	if m["level"] != "INFO" || m["msg"] != "hello" || m["service"] != "orders" || m["k"] != "v" || m["n"].(float64) != 2 {
+		t.Fatalf("unexpected slog output: %v", m)
+	}
+
+	buf.Reset()
+	f.Trace().Msg("hidden")
+	if buf.Len() != 0 {
+		t.Fatalf("expected trace to be filtered, got: %s", buf.String())
+	}
+	f.SetLevel(logger.TraceLevel)
+	f.Trace().Msg("visible")
+	if !strings.Contains(buf.String(), "visible") {
+		t.Fatalf("expected trace after SetLevel, got: %s", buf.String())
+	}
+
+	buf.Reset()
+	ctx := tr.ContextWithTrace(context.Background(), "trace-2")
+	f.WithTraceFromContext(ctx).Error(errors.New("boom")).Msgf("failed %s", "start")
+	m = decodeLine(t, buf.Bytes())
+	if m["level"] != "ERROR" || m["error"] != "boom" || m["trace_id"] != "trace-2" || m["msg"] != "failed start" {
+		t.Fatalf("unexpected error output: %v", m)
+	}
+}
+
+func TestSlogBackedFacadePanics(t *testing.T) {
+	buf := &bytes.Buffer{}
+	f := adapter.NewSlogLogger(slog.NewJSONHandler(buf, nil), logger.ErrorLevel)
+
+	defer func() {
+		if r := recover(); r != "fatal state" {
+			t.Fatalf("expected panic with message, got: %v", r)
+		}
+		if !strings.Contains(buf.String(), "fatal state") {
+			t.Fatalf("expected panic record to be written, got: %s", buf.String())
+		}
+	}()
+	f.Panic(nil).Msg("fatal state")
+}
@@ -0,0 +1,37 @@
+package log_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	logger "github.com/totvs/go-sdk/log"
+	"github.com/totvs/go-sdk/log/logtest"
+	middleware "github.com/totvs/go-sdk/log/middleware"
+	"github.com/totvs/go-sdk/trace"
+)
+
+func TestMiddlewareTraceIDValidation(t *testing.T) {
+	noop := http.HandlerFunc(func(http.ResponseWriter, *http.Request) {})
+	bad := strings.Repeat("x", 300)
+
+	rec := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set(trace.TraceIDHTTPHeader, bad)
+	middleware.HTTPMiddlewareWithLogger(logtest.New())(noop).ServeHTTP(rec, req)
+	if got := rec.Header().Get(trace.TraceIDHTTPHeader); got == bad || !trace.IsValidTraceID(got) {
+		t.Fatalf("expected the invalid id to be regenerated, got %q", got)
+	}
+
+	trace.SetValidator(trace.Validator{Policy: trace.RejectInvalidID})
+	defer trace.SetValidator(trace.Validator{})
+	l := logtest.New()
+	called := false
+	rec = httptest.NewRecorder()
+	middleware.HTTPMiddlewareWithLogger(l)(http.HandlerFunc(func(http.ResponseWriter, *http.Request) { called = true })).ServeHTTP(rec, req)
+	if called || rec.Code != http.StatusBadRequest || !strings.Contains(rec.Body.String(), "invalid trace id") {
+		t.Fatalf("expected a 400 without calling the handler, got %d %q", rec.Code, rec.Body.String())
+	}
+	logtest.AssertLogged(t, l, logtest.Level(logger.WarnLevel), logtest.MessageContains("invalid trace id"))
+}
@@ -0,0 +1,124 @@
+package log_test
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	logger "github.com/totvs/go-sdk/log"
+	adapter "github.com/totvs/go-sdk/log/adapter"
+	middleware "github.com/totvs/go-sdk/log/middleware"
+	"github.com/totvs/go-sdk/log/middleware/ginlog"
+	"github.com/totvs/go-sdk/trace"
+	tradapter "github.com/totvs/go-sdk/trace/adapter"
+	"go.opentelemetry.io/otel/codes"
+)
+
+func TestMiddlewareStartsServerSpan(t *testing.T) {
+	setup, exporter, err := tradapter.NewInMemoryTracing("orders")
+	if err != nil {
+		t.Fatalf("setup failed: %v", err)
+	}
+	defer setup.Shutdown()
+
+	buf := &bytes.Buffer{}
+	opts := middleware.MiddlewareOptions{AccessLog: true, TracerProvider: setup.Provider}
+	h := middleware.HTTPMiddlewareWithOptions(adapter.NewLog(buf, logger.InfoLevel), opts)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	req := httptest.NewRequest(http.MethodGet, "/items", nil)
+	req.Header.Set(trace.TraceParentHeader, "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+	h.ServeHTTP(httptest.NewRecorder(), req)
+
+	spans := exporter.GetSpans()
+	if len(spans) != 1 {
+		t.Fatalf("expected one server span, got %d", len(spans))
+	}
+	s := spans[0]
+	if s.Name != "GET" || s.SpanContext.TraceID().String() != "4bf92f3577b34da6a3ce929d0e0e4736" ||
+		s.Parent.SpanID().String() != "00f067aa0ba902b7" || s.Status.Code != codes.Error {
+		t.Fatalf("unexpected span: %+v", s)
+	}
+	m := findLine(buf.String(), "http request completed")
+	if m == nil || m["span_id"] != s.SpanContext.SpanID().String() {
+		t.Fatalf("expected the completion log to carry the span id, got %v", m)
+	}
+}
+
+func TestMiddlewareKeepsLegacyTraceIDWithTracerProvider(t *testing.T) {
+	setup, exporter, err := tradapter.NewInMemoryTracing("orders")
+	if err != nil {
+		t.Fatalf("setup failed: %v", err)
+	}
+	defer setup.Shutdown()
+
+	buf := &bytes.Buffer{}
+	var handlerTID string
+	opts := middleware.MiddlewareOptions{AccessLog: true, AddTraceHeader: true, TracerProvider: setup.Provider}
+	h := middleware.HTTPMiddlewareWithOptions(adapter.NewLog(buf, logger.InfoLevel), opts)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		handlerTID = trace.TraceIDFromContext(r.Context())
+	}))
+	req := httptest.NewRequest(http.MethodGet, "/items", nil)
+	req.Header.Set(trace.TraceIDHTTPHeader, "legacy-abc-123")
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+
+	if got := rec.Header().Get(trace.TraceIDHTTPHeader); got != "legacy-abc-123" || handlerTID != "legacy-abc-123" {
+		t.Fatalf("expected the incoming id to be kept, got header %q and handler %q", got, handlerTID)
+	}
+	spans := exporter.GetSpans()
+	if len(spans) != 1 {
+		t.Fatalf("expected one server span, got %d", len(spans))
+	}
+	sc := spans[0].SpanContext
+	m := findLine(buf.String(), "http request completed")
+	if m == nil || m[trace.TraceIDField] != "legacy-abc-123" || m[trace.SpanIDField] != sc.SpanID().String() ||
+		m[trace.OTelTraceIDField] != sc.TraceID().String() {
+		t.Fatalf("expected the legacy trace_id with the span ids, got %v", m)
+	}
+}
+
+func TestGinMiddlewareSpanName(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	setup, exporter, err := tradapter.NewInMemoryTracing("orders")
+	if err != nil {
+		t.Fatalf("setup failed: %v", err)
+	}
+	defer setup.Shutdown()
+
+	r := gin.New()
+	opts := ginlog.DefaultOptions
+	opts.TracerProvider = setup.Provider
+	r.Use(ginlog.MiddlewareWithOptions(adapter.NewLog(&bytes.Buffer{}, logger.InfoLevel), opts))
+	r.GET("/items/:id", func(c *gin.Context) { c.Status(http.StatusOK) })
+	r.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/items/7", nil))
+
+	spans := exporter.GetSpans()
+	if len(spans) != 1 || spans[0].Name != "GET /items/:id" || !strings.HasPrefix(spans[0].InstrumentationScope.Name, "github.com/totvs/go-sdk") {
+		t.Fatalf("unexpected spans: %+v", spans)
+	}
+}
+
+func TestMiddlewareLogsTraceAndSpanIDs(t *testing.T) {
+	buf := &bytes.Buffer{}
+	var span string
+	h := middleware.HTTPMiddlewareWithLogger(adapter.NewLog(buf, logger.InfoLevel))(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		span = trace.SpanIDFromContext(r.Context())
+	}))
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set(trace.TraceParentHeader, "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+
+	out := buf.String()
+	if !strings.Contains(out, `"trace_id":"4bf92f3577b34da6a3ce929d0e0e4736"`) || span == "" ||
+		!strings.Contains(out, `"span_id":"`+span+`"`) {
+		t.Fatalf("expected trace_id and span_id in the request log, got: %s", out)
+	}
+	if rec.Header().Get(trace.TraceIDHTTPHeader) != "4bf92f3577b34da6a3ce929d0e0e4736" {
+		t.Fatalf("unexpected response header %q", rec.Header().Get(trace.TraceIDHTTPHeader))
+	}
+}
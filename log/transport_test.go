@@ -0,0 +1,103 @@
+package log_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	logger "github.com/totvs/go-sdk/log"
+	"github.com/totvs/go-sdk/log/logtest"
+	middleware "github.com/totvs/go-sdk/log/middleware"
+	mt "github.com/totvs/go-sdk/metrics"
+	"github.com/totvs/go-sdk/trace"
+	tradapter "github.com/totvs/go-sdk/trace/adapter"
+)
+
+func TestTransportPropagatesLogsAndCounts(t *testing.T) {
+	var got http.Header
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = r.Header.Clone()
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer srv.Close()
+
+	rec := logtest.New()
+	m := &countingMetrics{MetricsFacade: mt.GetGlobal(), name: middleware.ClientRequestsMetric}
+	client := &http.Client{Transport: middleware.Transport(nil, middleware.TransportOptions{Metrics: m})}
+
+	h := http.Header{}
+	h.Set(trace.TraceParentHeader, "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+	ctx := logger.ContextWithLogger(trace.ExtractHTTPHeaders(context.Background(), h), rec)
+	req, _ := http.NewRequestWithContext(ctx, http.MethodGet, srv.URL+"/items?token=secret", nil)
+	resp, err := client.Do(req)
+	if err != nil {
+		t.Fatalf("request failed: %v", err)
+	}
+	_ = resp.Body.Close()
+
+	p, err := trace.ParseTraceParent(got.Get(trace.TraceParentHeader))
+	if err != nil || p.TraceID != "4bf92f3577b34da6a3ce929d0e0e4736" || p.SpanID != trace.SpanIDFromContext(ctx) {
+		t.Fatalf("unexpected propagated traceparent %q", got.Get(trace.TraceParentHeader))
+	}
+	if got.Get(trace.TraceIDHTTPHeader) != "4bf92f3577b34da6a3ce929d0e0e4736" || req.Header.Get(trace.TraceParentHeader) != "" {
+		t.Fatalf("expected X-Request-Id on the wire and the caller request untouched, got %v", got)
+	}
+	logtest.AssertLogged(t, rec, logtest.Level(logger.WarnLevel), logtest.Message(middleware.ClientCompletedMessage),
+		logtest.Field("status", http.StatusServiceUnavailable), logtest.Field("url", srv.URL+"/items"),
+		logtest.Field(trace.TraceIDField, "4bf92f3577b34da6a3ce929d0e0e4736"))
+	if m.n.Load() != 1 {
+		t.Fatalf("expected one counted request, got %d", m.n.Load())
+	}
+}
+
+func TestTransportKeepsHeadersAndLogsFailures(t *testing.T) {
+	failing := roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+		if r.Header.Get(trace.TraceIDHTTPHeader) != "caller-id" {
+			t.Errorf("expected the caller header to be kept, got %q", r.Header.Get(trace.TraceIDHTTPHeader))
+		}
+		return nil, errors.New("connection refused")
+	})
+	rec := logtest.New()
+	client := &http.Client{Transport: middleware.Transport(failing, middleware.TransportOptions{Logger: rec})}
+
+	req, _ := http.NewRequestWithContext(trace.ContextWithTrace(context.Background(), "ctx-id"), http.MethodPost, "http://example.invalid/x", nil)
+	req.Header.Set(trace.TraceIDHTTPHeader, "caller-id")
+	if _, err := client.Do(req); err == nil {
+		t.Fatal("expected the transport error")
+	}
+	logtest.AssertLogged(t, rec, logtest.Level(logger.ErrorLevel), logtest.Message(middleware.ClientFailedMessage),
+		logtest.ErrContains("connection refused"), logtest.Field(trace.TraceIDField, "ctx-id"))
+}
+
+func TestTransportClientSpan(t *testing.T) {
+	setup, exporter, err := tradapter.NewInMemoryTracing("orders")
+	if err != nil {
+		t.Fatalf("setup failed: %v", err)
+	}
+	defer setup.Shutdown()
+
+	var parent string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		p, _ := trace.ParseTraceParent(r.Header.Get(trace.TraceParentHeader))
+		parent = p.SpanID
+	}))
+	defer srv.Close()
+
+	client := &http.Client{Transport: middleware.Transport(nil, middleware.TransportOptions{Logger: logtest.New(), TracerProvider: setup.Provider})}
+	resp, err := client.Get(srv.URL)
+	if err != nil {
+		t.Fatalf("request failed: %v", err)
+	}
+	_ = resp.Body.Close()
+
+	spans := exporter.GetSpans()
+	if len(spans) != 1 || spans[0].SpanContext.SpanID().String() != parent {
+		t.Fatalf("expected the client span to be propagated, got %q and %+v", parent, spans)
+	}
+}
+
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(r *http.Request) (*http.Response, error) { return f(r) }
@@ -0,0 +1,77 @@
+package util
+
+import (
+	"encoding/json"
+	"net/http"
+
+	lg "github.com/totvs/go-sdk/log"
+)
+
+// levelPayload is the JSON document exchanged by the level handler.
+type levelPayload struct {
+	Level   string            `json:"level,omitempty"`
+	Logger  string            `json:"logger,omitempty"`
+	Loggers map[string]string `json:"loggers,omitempty"`
+	Error   string            `json:"error,omitempty"`
+}
+
+// NewLevelHandler retorna um http.Handler para consultar e alterar o nível de
+// log em tempo de execução.
+//
+//   - GET devolve o nível base e os overrides por nome de logger:
+//     `{"level":"info","loggers":{"controller":"debug"}}`.
+//   - PUT com `{"level":"debug"}` altera o nível base; com
+//     `{"logger":"controller","level":"debug"}` define um override para o
+//     logger nomeado e com `{"logger":"controller"}` (sem nível) remove o override.
+//
+// O handler não aplica autenticação: exponha-o apenas em portas internas ou
+// atrás de um middleware de autorização.
+func NewLevelHandler(level *lg.AtomicLevel) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+		case http.MethodPut:
+			var req levelPayload
+			if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, 1<<10)).Decode(&req); err != nil {
+				writeLevelPayload(w, http.StatusBadRequest, levelPayload{Error: "invalid JSON body"})
+				return
+			}
+			if req.Logger != "" && req.Level == "" {
+				level.ClearNamedLevel(req.Logger)
+				break
+			}
+			l, ok := lg.ParseLevel(req.Level)
+			if !ok {
+				writeLevelPayload(w, http.StatusBadRequest, levelPayload{Error: "unknown level: " + req.Level})
+				return
+			}
+			if req.Logger != "" {
+				level.SetNamedLevel(req.Logger, l)
+			} else {
+				level.SetLevel(l)
+			}
+		default:
+			w.Header().Set("Allow", "GET, PUT")
+			writeLevelPayload(w, http.StatusMethodNotAllowed, levelPayload{Error: "method not allowed"})
+			return
+		}
+		writeLevelPayload(w, http.StatusOK, currentLevels(level))
+	})
+}
+
+func currentLevels(level *lg.AtomicLevel) levelPayload {
+	out := levelPayload{Level: level.Level().String()}
+	if named := level.NamedLevels(); len(named) > 0 {
+		out.Loggers = make(map[string]string, len(named))
+		for k, v := range named {
+			out.Loggers[k] = v.String()
+		}
+	}
+	return out
+}
+
+func writeLevelPayload(w http.ResponseWriter, status int, p levelPayload) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(p)
+}
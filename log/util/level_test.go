@@ -0,0 +1,67 @@
+package util_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	lg "github.com/totvs/go-sdk/log"
+	"github.com/totvs/go-sdk/log/util"
+)
+
+func doLevelRequest(t *testing.T, h http.Handler, method, body string) (int, map[string]interface{}) {
+	t.Helper()
+	req := httptest.NewRequest(method, "/debug/log/level", strings.NewReader(body))
+	rr := httptest.NewRecorder()
+	h.ServeHTTP(rr, req)
+	var m map[string]interface{}
+	if err := json.Unmarshal(rr.Body.Bytes(), &m); err != nil {
+		t.Fatalf("invalid json: %v, raw: %s", err, rr.Body.String())
+	}
+	return rr.Code, m
+}
+
+func TestLevelHandlerGetAndPut(t *testing.T) {
+	lvl := lg.NewAtomicLevel(lg.InfoLevel)
+	h := util.NewLevelHandler(lvl)
+
+	code, m := doLevelRequest(t, h, http.MethodGet, "")
+	if code != http.StatusOK || m["level"] != "info" {
+		t.Fatalf("unexpected GET response: %d %v", code, m)
+	}
+
+	code, m = doLevelRequest(t, h, http.MethodPut, `{"level":"debug"}`)
+	if code != http.StatusOK || m["level"] != "debug" || lvl.Level() != lg.DebugLevel {
+		t.Fatalf("unexpected PUT response: %d %v", code, m)
+	}
+
+	code, m = doLevelRequest(t, h, http.MethodPut, `{"logger":"controller","level":"error"}`)
+	if code != http.StatusOK {
+		t.Fatalf("unexpected status: %d", code)
+	}
+	loggers, _ := m["loggers"].(map[string]interface{})
+	if loggers["controller"] != "error" || lvl.LevelFor("controller") != lg.ErrorLevel {
+		t.Fatalf("expected named override, got: %v", m)
+	}
+
+	doLevelRequest(t, h, http.MethodPut, `{"logger":"controller"}`)
+	if lvl.LevelFor("controller") != lg.DebugLevel {
+		t.Fatalf("expected override to be cleared")
+	}
+}
+
+func TestLevelHandlerRejectsInvalidInput(t *testing.T) {
+	h := util.NewLevelHandler(lg.NewAtomicLevel(lg.InfoLevel))
+
+	if code, _ := doLevelRequest(t, h, http.MethodPut, `{"level":"verbose"}`); code != http.StatusBadRequest {
+		t.Fatalf("expected 400 for unknown level, got: %d", code)
+	}
+	if code, _ := doLevelRequest(t, h, http.MethodPut, `not-json`); code != http.StatusBadRequest {
+		t.Fatalf("expected 400 for invalid body, got: %d", code)
+	}
+	if code, _ := doLevelRequest(t, h, http.MethodPost, `{}`); code != http.StatusMethodNotAllowed {
+		t.Fatalf("expected 405, got: %d", code)
+	}
+}
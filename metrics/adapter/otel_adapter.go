@@ -8,9 +8,10 @@ import (
 
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
-	otelprom "go.opentelemetry.io/otel/exporters/prometheus"
+	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/metric"
 	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/resource"
 
 	mt "github.com/totvs/go-sdk/metrics"
 	backend "github.com/totvs/go-sdk/metrics/internal/backend"
@@ -19,6 +20,9 @@ import (
 type TOTVSMetricsConfig struct {
 	ServiceName string // ServiceName is the name of the service generating metrics
 	Platform    string // Examples: "totvs.apps", "erp.protheus", "fluig.apps", "carol.apps"
+	// OTLP, when set, also pushes the metrics to an OTLP collector from the
+	// same MeterProvider (see OTLPConfig and OTLPConfigFromEnv).
+	OTLP *OTLPConfig
 }
 
 // Validate checks if the TOTVS configuration has valid values
@@ -31,6 +35,10 @@ func (c TOTVSMetricsConfig) Validate() error {
 		return fmt.Errorf("platform is required (example: totvs.apps)")
 	}
 
+	if c.OTLP != nil {
+		return c.OTLP.validate()
+	}
+
 	return nil
 }
 
@@ -53,7 +61,16 @@ func (s *DefaultMetricsSetup) ServiceName() string {
 	return s.serviceName
 }
 
-// Shutdown gracefully shuts down the metrics provider.
+// ForceFlush exports the measurements not yet pushed by the OTLP reader.
+func (s *DefaultMetricsSetup) ForceFlush(ctx context.Context) error {
+	if s.provider == nil {
+		return nil
+	}
+	return s.provider.ForceFlush(ctx)
+}
+
+// Shutdown gracefully shuts down the metrics provider (pushing the last
+// measurements when OTLP is enabled).
 func (s *DefaultMetricsSetup) Shutdown() error {
 	var err error
 	s.shutdownOnce.Do(func() {
@@ -65,19 +82,24 @@ func (s *DefaultMetricsSetup) Shutdown() error {
 }
 
 // Handler returns a ready-to-use HTTP handler for the /metrics endpoint.
-// Uses default Prometheus handler options.
+// Uses default Prometheus handler options. Setups without a Prometheus
+// registry (NewOTLPMetrics) answer 404.
 func (s *DefaultMetricsSetup) Handler() http.Handler {
-	return promhttp.HandlerFor(s.Registry, promhttp.HandlerOpts{})
+	return s.HandlerWithOpts(promhttp.HandlerOpts{})
 }
 
 // HandlerWithOpts returns an HTTP handler with custom Prometheus options.
 // Use this when you need to customize behavior like timeout, error handling,
 // compression, or instrumentation.
 func (s *DefaultMetricsSetup) HandlerWithOpts(opts promhttp.HandlerOpts) http.Handler {
+	if s.Registry == nil {
+		return http.NotFoundHandler()
+	}
 	return promhttp.HandlerFor(s.Registry, opts)
 }
 
-// NewDefaultMetrics creates a metrics setup with Prometheus exporter.
+// NewDefaultMetrics creates a metrics setup with Prometheus exporter (plus the
+// OTLP push exporter when cfg.OTLP is set).
 func NewDefaultMetrics(cfg TOTVSMetricsConfig) (*DefaultMetricsSetup, error) {
 	// Validate configuration
 	if err := cfg.Validate(); err != nil {
@@ -87,29 +109,15 @@ func NewDefaultMetrics(cfg TOTVSMetricsConfig) (*DefaultMetricsSetup, error) {
 	// Create isolated Prometheus registry
 	registry := prometheus.NewRegistry()
 
-	// Create Prometheus exporter for OpenTelemetry
-	exporter, err := otelprom.New(
-		otelprom.WithRegisterer(registry),
-	)
+	// Create MeterProvider with the Prometheus exporter (and the OTLP reader, if configured)
+	views := backend.NewViews()
+	provider, err := newProvider(cfg, views, prometheusReader(registry))
 	if err != nil {
-		return nil, fmt.Errorf("failed to create prometheus exporter: %w", err)
-	}
-
-	// Create MeterProvider with the exporter
-	provider := sdkmetric.NewMeterProvider(
-		sdkmetric.WithReader(exporter),
-	)
-
-	// Create meter
-	meter := provider.Meter(cfg.ServiceName)
-
-	// default labels
-	defaultLabels := []mt.Attribute{
-		mt.Attr("platform", cfg.Platform),
+		return nil, err
 	}
 
 	// Create metrics facade with TOTVS labels
-	metrics := backend.NewMetricsWithAttributes(meter, defaultLabels)
+	metrics := newFacade(provider, views, cfg)
 
 	return &DefaultMetricsSetup{
 		Metrics:     metrics,
@@ -133,24 +141,13 @@ func NewMetricsWithRegistry(registry prometheus.Registerer, cfg TOTVSMetricsConf
 		return nil, fmt.Errorf("invalid TOTVS configuration: %w", err)
 	}
 
-	exporter, err := otelprom.New(
-		otelprom.WithRegisterer(registry),
-	)
+	views := backend.NewViews()
+	provider, err := newProvider(cfg, views, prometheusReader(registry))
 	if err != nil {
-		return nil, fmt.Errorf("failed to create prometheus exporter: %w", err)
-	}
-
-	provider := sdkmetric.NewMeterProvider(
-		sdkmetric.WithReader(exporter),
-	)
-
-	meter := provider.Meter(cfg.ServiceName)
-
-	defaultLabels := []mt.Attribute{
-		mt.Attr("platform", cfg.Platform),
+		return nil, err
 	}
 
-	metrics := backend.NewMetricsWithAttributes(meter, defaultLabels)
+	metrics := newFacade(provider, views, cfg)
 
 	var reg *prometheus.Registry
 	if r, ok := registry.(*prometheus.Registry); ok {
@@ -165,6 +162,54 @@ func NewMetricsWithRegistry(registry prometheus.Registerer, cfg TOTVSMetricsConf
 	}, nil
 }
 
+// newFacade creates the facade of the TOTVS setups, with the platform label.
+func newFacade(provider metric.MeterProvider, views *Views, cfg TOTVSMetricsConfig) mt.MetricsFacade {
+	defaultLabels := []mt.Attribute{
+		mt.Attr("platform", cfg.Platform),
+	}
+	return backend.NewMetricsWithViews(provider.Meter(cfg.ServiceName), views, defaultLabels)
+}
+
+// newResource describes the service (service.name and platform, when set) on
+// top of the default resource, which honors OTEL_RESOURCE_ATTRIBUTES. It is
+// only attached to providers pushing through OTLP, so the Prometheus output
+// (target_info) of the other setups is unchanged.
+func newResource(cfg TOTVSMetricsConfig) (*resource.Resource, error) {
+	attrs := []attribute.KeyValue{attribute.String("service.name", cfg.ServiceName)}
+	if cfg.Platform != "" {
+		attrs = append(attrs, attribute.String("platform", cfg.Platform))
+	}
+	res, err := resource.Merge(resource.Default(), resource.NewSchemaless(attrs...))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create metrics resource: %w", err)
+	}
+	return res, nil
+}
+
+// Views applies the aggregations requested through metric options
+// (metrics.WithExponentialBuckets) in one MeterProvider. The setups of this
+// package create their own; for a custom provider install a new one and
+// pass it to NewMetricsWithViews:
+//
+//	views := adapter.NewViews()
+//	provider := sdkmetric.NewMeterProvider(
+//	    sdkmetric.WithReader(reader),
+//	    sdkmetric.WithView(views.View),
+//	)
+//	m := adapter.NewMetricsWithViews(provider, "my-service", views)
+type Views = backend.Views
+
+// NewViews creates the Views of a new MeterProvider.
+func NewViews() *Views {
+	return backend.NewViews()
+}
+
+// NewMetricsWithViews creates a MetricsFacade on a custom provider that
+// installs views.View.
+func NewMetricsWithViews(provider metric.MeterProvider, serviceName string, views *Views) mt.MetricsFacade {
+	return backend.NewMetricsWithViews(provider.Meter(serviceName), views, nil)
+}
+
 // NewMetricsWithProvider delegates to the internal OpenTelemetry backend with a custom provider.
 func NewMetricsWithProvider(provider metric.MeterProvider, serviceName string) mt.MetricsFacade {
 	return backend.NewMetricsWithProvider(provider, serviceName)
@@ -0,0 +1,185 @@
+package adapter
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetricgrpc"
+	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetrichttp"
+	otelprom "go.opentelemetry.io/otel/exporters/prometheus"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+
+	backend "github.com/totvs/go-sdk/metrics/internal/backend"
+)
+
+// OTLP protocols, as accepted by OTEL_EXPORTER_OTLP_PROTOCOL.
+const (
+	OTLPProtocolGRPC = "grpc"
+	OTLPProtocolHTTP = "http/protobuf"
+)
+
+// OTLPConfig configures the OTLP push exporter. Endpoint, headers, TLS
+// certificates, compression and timeout are read by the exporter from the
+// standard OTEL_EXPORTER_OTLP_* and OTEL_EXPORTER_OTLP_METRICS_* env vars;
+// non-zero fields override them.
+type OTLPConfig struct {
+	// Protocol is OTLPProtocolGRPC or OTLPProtocolHTTP. Defaults to
+	// OTEL_EXPORTER_OTLP_METRICS_PROTOCOL, OTEL_EXPORTER_OTLP_PROTOCOL and
+	// then http/protobuf.
+	Protocol string
+	// EndpointURL is the collector URL, e.g. "http://otel-collector:4318"
+	// (http/protobuf appends /v1/metrics when there is no path). An http
+	// scheme disables TLS.
+	EndpointURL string
+	// Interval between exports. Defaults to OTEL_METRIC_EXPORT_INTERVAL and
+	// then 60s.
+	Interval time.Duration
+}
+
+// OTLPConfigFromEnv returns an empty OTLPConfig (fully configured by the env
+// vars) and true when OTEL_EXPORTER_OTLP_ENDPOINT or
+// OTEL_EXPORTER_OTLP_METRICS_ENDPOINT is set, so the push exporter can be
+// enabled per deployment:
+//
+//	cfg := adapter.TOTVSMetricsConfig{ServiceName: "billing-job", Platform: "totvs.apps"}
+//	if otlp, ok := adapter.OTLPConfigFromEnv(); ok {
+//	    cfg.OTLP = &otlp
+//	}
+func OTLPConfigFromEnv() (OTLPConfig, bool) {
+	for _, k := range []string{"OTEL_EXPORTER_OTLP_METRICS_ENDPOINT", "OTEL_EXPORTER_OTLP_ENDPOINT"} {
+		if os.Getenv(k) != "" {
+			return OTLPConfig{}, true
+		}
+	}
+	return OTLPConfig{}, false
+}
+
+func (c OTLPConfig) protocol() string {
+	if c.Protocol != "" {
+		return c.Protocol
+	}
+	for _, k := range []string{"OTEL_EXPORTER_OTLP_METRICS_PROTOCOL", "OTEL_EXPORTER_OTLP_PROTOCOL"} {
+		if p := strings.TrimSpace(os.Getenv(k)); p != "" {
+			return p
+		}
+	}
+	return OTLPProtocolHTTP
+}
+
+func (c OTLPConfig) validate() error {
+	if p := c.protocol(); p != OTLPProtocolGRPC && p != OTLPProtocolHTTP {
+		return fmt.Errorf("unsupported OTLP protocol %q (use %q or %q)", p, OTLPProtocolGRPC, OTLPProtocolHTTP)
+	}
+	return nil
+}
+
+// NewOTLPExporter creates the OTLP metric exporter (gRPC or HTTP) described by cfg.
+func NewOTLPExporter(ctx context.Context, cfg OTLPConfig) (sdkmetric.Exporter, error) {
+	if err := cfg.validate(); err != nil {
+		return nil, err
+	}
+	if cfg.protocol() == OTLPProtocolGRPC {
+		var opts []otlpmetricgrpc.Option
+		if cfg.EndpointURL != "" {
+			opts = append(opts, otlpmetricgrpc.WithEndpointURL(cfg.EndpointURL))
+		}
+		return otlpmetricgrpc.New(ctx, opts...)
+	}
+	var opts []otlpmetrichttp.Option
+	if cfg.EndpointURL != "" {
+		opts = append(opts, otlpmetrichttp.WithEndpointURL(cfg.EndpointURL))
+	}
+	return otlpmetrichttp.New(ctx, opts...)
+}
+
+// NewOTLPReader creates a periodic reader pushing to the OTLP exporter
+// described by cfg. It can be combined with the Prometheus exporter in the
+// same MeterProvider (see TOTVSMetricsConfig.OTLP).
+func NewOTLPReader(ctx context.Context, cfg OTLPConfig) (sdkmetric.Reader, error) {
+	exporter, err := NewOTLPExporter(ctx, cfg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create OTLP exporter: %w", err)
+	}
+	var opts []sdkmetric.PeriodicReaderOption
+	if cfg.Interval > 0 {
+		opts = append(opts, sdkmetric.WithInterval(cfg.Interval))
+	}
+	return sdkmetric.NewPeriodicReader(exporter, opts...), nil
+}
+
+// NewOTLPMetrics cria um setup que apenas envia as métricas via OTLP (sem
+// endpoint /metrics), para jobs de curta duração ou ambientes com coletor.
+// Usa cfg.OTLP ou, quando nil, somente as variáveis OTEL_EXPORTER_OTLP_*.
+// Chame Shutdown antes de encerrar o processo para enviar as últimas medições.
+func NewOTLPMetrics(cfg TOTVSMetricsConfig) (*DefaultMetricsSetup, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid TOTVS configuration: %w", err)
+	}
+	if cfg.OTLP == nil {
+		cfg.OTLP = &OTLPConfig{}
+	}
+
+	views := backend.NewViews()
+	provider, err := newProvider(cfg, views, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return &DefaultMetricsSetup{
+		Metrics:     newFacade(provider, views, cfg),
+		provider:    provider,
+		serviceName: cfg.ServiceName,
+	}, nil
+}
+
+// newProvider creates the MeterProvider of the setups with views, the reader
+// returned by newReader (when not nil) and, when cfg.OTLP is set, the OTLP
+// reader and the service resource. Everything that can fail is created before
+// newReader is called, so an error never leaves a Prometheus collector
+// registered.
+func newProvider(cfg TOTVSMetricsConfig, views *Views, newReader func() (sdkmetric.Reader, error)) (*sdkmetric.MeterProvider, error) {
+	opts := []sdkmetric.Option{sdkmetric.WithView(views.View)}
+
+	var otlpReader sdkmetric.Reader
+	if cfg.OTLP != nil {
+		res, err := newResource(cfg)
+		if err != nil {
+			return nil, err
+		}
+		otlpReader, err = NewOTLPReader(context.Background(), *cfg.OTLP)
+		if err != nil {
+			return nil, err
+		}
+		opts = append(opts, sdkmetric.WithResource(res), sdkmetric.WithReader(otlpReader))
+	}
+
+	if newReader != nil {
+		reader, err := newReader()
+		if err != nil {
+			if otlpReader != nil {
+				_ = otlpReader.Shutdown(context.Background())
+			}
+			return nil, err
+		}
+		opts = append(opts, sdkmetric.WithReader(reader))
+	}
+	return sdkmetric.NewMeterProvider(opts...), nil
+}
+
+// prometheusReader returns the newProvider reader factory registering the
+// Prometheus exporter in registry.
+func prometheusReader(registry prometheus.Registerer) func() (sdkmetric.Reader, error) {
+	return func() (sdkmetric.Reader, error) {
+		exporter, err := otelprom.New(
+			otelprom.WithRegisterer(registry),
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create prometheus exporter: %w", err)
+		}
+		return exporter, nil
+	}
+}
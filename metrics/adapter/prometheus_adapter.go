@@ -4,37 +4,50 @@ import (
 	"fmt"
 
 	"github.com/prometheus/client_golang/prometheus"
-	otelprom "go.opentelemetry.io/otel/exporters/prometheus"
-	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
 
 	backend "github.com/totvs/go-sdk/metrics/internal/backend"
 )
 
+// PrometheusOption configures NewPrometheusMetrics.
+type PrometheusOption func(*TOTVSMetricsConfig)
+
+// WithOTLP also pushes the metrics to an OTLP collector from the same
+// MeterProvider (see OTLPConfig and OTLPConfigFromEnv).
+func WithOTLP(cfg OTLPConfig) PrometheusOption {
+	return func(c *TOTVSMetricsConfig) {
+		c.OTLP = &cfg
+	}
+}
+
 // NewPrometheusMetrics creates a simple Prometheus metrics setup
-func NewPrometheusMetrics(serviceName string) (*DefaultMetricsSetup, error) {
+func NewPrometheusMetrics(serviceName string, opts ...PrometheusOption) (*DefaultMetricsSetup, error) {
 	if serviceName == "" {
 		return nil, fmt.Errorf("ServiceName is required")
 	}
 
+	cfg := TOTVSMetricsConfig{ServiceName: serviceName}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	if cfg.OTLP != nil {
+		if err := cfg.OTLP.validate(); err != nil {
+			return nil, fmt.Errorf("invalid OTLP configuration: %w", err)
+		}
+	}
+
 	// Create isolated Prometheus registry
 	registry := prometheus.NewRegistry()
 
-	// Create Prometheus exporter for OpenTelemetry
-	exporter, err := otelprom.New(
-		otelprom.WithRegisterer(registry),
-	)
+	// Create MeterProvider with the Prometheus exporter (and the OTLP reader, if configured)
+	views := backend.NewViews()
+	provider, err := newProvider(cfg, views, prometheusReader(registry))
 	if err != nil {
-		return nil, fmt.Errorf("failed to create prometheus exporter: %w", err)
+		return nil, err
 	}
 
-	// Create MeterProvider with the exporter
-	provider := sdkmetric.NewMeterProvider(
-		sdkmetric.WithReader(exporter),
-	)
-
 	// Create meter (no default labels)
 	meter := provider.Meter(serviceName)
-	metrics := backend.NewMetrics(meter)
+	metrics := backend.NewMetricsWithViews(meter, views, nil)
 
 	return &DefaultMetricsSetup{
 		Metrics:     metrics,
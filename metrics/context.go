@@ -0,0 +1,103 @@
+package metrics
+
+import (
+	"context"
+	"sort"
+
+	"github.com/totvs/go-sdk/internal/ctxfields"
+	"github.com/totvs/go-sdk/trace"
+)
+
+// Attribute keys added by the built-in context extractors.
+const (
+	TenantIDAttr = "tenant_id"
+	RouteAttr    = "route"
+	ClientIDAttr = "client_id"
+)
+
+// Names of the built-in context extractors (see RegisterContextExtractor).
+const (
+	TenantExtractor   = "tenant"
+	RouteExtractor    = "route"
+	ClientIDExtractor = "client_id"
+)
+
+const (
+	routeKey    ctxKey = "route"
+	clientIDKey ctxKey = "client-id"
+)
+
+// ContextExtractor reads values from ctx and reports them as metric
+// attributes through set. Extractors run on every WithAttributesFromContext
+// call, so they must be cheap, safe for concurrent use and only report
+// low-cardinality values (never trace or request ids).
+type ContextExtractor func(ctx context.Context, set func(k string, v any))
+
+func init() {
+	for _, k := range []ctxKey{routeKey, clientIDKey} {
+		trace.RegisterDetachKey(k)
+	}
+}
+
+// extractors is the registry applied by ContextAttributes, starting with the
+// built-in extractors.
+var extractors = ctxfields.NewRegistry(
+	ctxfields.Named{Name: TenantExtractor, Fn: ctxfields.TenantID(TenantIDAttr)},
+	ctxfields.Named{Name: RouteExtractor, Fn: ctxfields.StringValue(routeKey, RouteAttr)},
+	ctxfields.Named{Name: ClientIDExtractor, Fn: ctxfields.StringValue(clientIDKey, ClientIDAttr)},
+)
+
+// RegisterContextExtractor adds (or replaces, when name is already
+// registered) an extractor applied by WithAttributesFromContext. Extractors
+// run in registration order; a later extractor overrides attributes set by
+// earlier ones. A nil fn removes the extractor.
+func RegisterContextExtractor(name string, fn ContextExtractor) {
+	extractors.Register(name, ctxfields.Extractor(fn))
+}
+
+// UnregisterContextExtractor removes the extractor registered as name.
+func UnregisterContextExtractor(name string) { RegisterContextExtractor(name, nil) }
+
+// ContextAttributes runs the registered extractors on ctx and returns the
+// collected attributes sorted by key (nil when there are none).
+func ContextAttributes(ctx context.Context) []Attribute {
+	if ctx == nil {
+		return nil
+	}
+	var values map[string]any
+	set := func(k string, v any) {
+		if values == nil {
+			values = make(map[string]any, 4)
+		}
+		values[k] = v
+	}
+	extractors.Run(ctx, set)
+	if len(values) == 0 {
+		return nil
+	}
+	attrs := make([]Attribute, 0, len(values))
+	for k, v := range values {
+		attrs = append(attrs, Attribute{Key: k, Value: v})
+	}
+	sort.Slice(attrs, func(i, j int) bool { return attrs[i].Key < attrs[j].Key })
+	return attrs
+}
+
+// ContextWithTenantID returns a context carrying the tenant id recorded as
+// tenant_id. The same value is logged by the log package (see
+// log.ContextWithTenantID), so the tenant is set once for both.
+func ContextWithTenantID(ctx context.Context, id string) context.Context {
+	return ctxfields.ContextWithTenantID(ctx, id)
+}
+
+// ContextWithRoute returns a context carrying the route template (e.g.
+// "/users/:id", never the raw path) recorded as route.
+func ContextWithRoute(ctx context.Context, route string) context.Context {
+	return context.WithValue(ctx, routeKey, route)
+}
+
+// ContextWithClientID returns a context carrying the client (application) id
+// recorded as client_id.
+func ContextWithClientID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, clientIDKey, id)
+}
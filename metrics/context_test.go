@@ -0,0 +1,68 @@
+package metrics_test
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	logger "github.com/totvs/go-sdk/log"
+	mt "github.com/totvs/go-sdk/metrics"
+	"github.com/totvs/go-sdk/trace"
+)
+
+type planKey struct{}
+
+func TestContextAttributes(t *testing.T) {
+	ctx := mt.ContextWithTenantID(context.Background(), "acme")
+	ctx = mt.ContextWithRoute(ctx, "/orders/:id")
+	ctx = mt.ContextWithClientID(ctx, "billing")
+
+	want := []mt.Attribute{
+		mt.Attr(mt.ClientIDAttr, "billing"),
+		mt.Attr(mt.RouteAttr, "/orders/:id"),
+		mt.Attr(mt.TenantIDAttr, "acme"),
+	}
+	if got := mt.ContextAttributes(ctx); !reflect.DeepEqual(got, want) {
+		t.Fatalf("expected %v, got %v", want, got)
+	}
+	if got := mt.ContextAttributes(context.Background()); got != nil {
+		t.Fatalf("expected no attributes, got %v", got)
+	}
+
+	// The values survive trace.Detach.
+	if got := mt.ContextAttributes(trace.Detach(ctx)); !reflect.DeepEqual(got, want) {
+		t.Fatalf("expected %v after Detach, got %v", want, got)
+	}
+}
+
+func TestTenantIDSharedWithLogs(t *testing.T) {
+	ctx := logger.ContextWithTenantID(context.Background(), "acme")
+	want := []mt.Attribute{mt.Attr(mt.TenantIDAttr, "acme")}
+	if got := mt.ContextAttributes(ctx); !reflect.DeepEqual(got, want) {
+		t.Fatalf("expected the tenant set for logs, got %v", got)
+	}
+	ctx = mt.ContextWithTenantID(context.Background(), "globex")
+	if got := logger.ContextFields(ctx)[logger.TenantIDField]; got != "globex" {
+		t.Fatalf("expected the tenant set for metrics in the log fields, got %v", got)
+	}
+}
+
+func TestRegisterContextExtractor(t *testing.T) {
+	mt.RegisterContextExtractor("plan", func(ctx context.Context, set func(string, any)) {
+		if p, ok := ctx.Value(planKey{}).(string); ok {
+			set("plan", p)
+		}
+	})
+	defer mt.UnregisterContextExtractor("plan")
+
+	ctx := context.WithValue(mt.ContextWithTenantID(context.Background(), "acme"), planKey{}, "gold")
+	want := []mt.Attribute{mt.Attr("plan", "gold"), mt.Attr(mt.TenantIDAttr, "acme")}
+	if got := mt.ContextAttributes(ctx); !reflect.DeepEqual(got, want) {
+		t.Fatalf("expected %v, got %v", want, got)
+	}
+
+	mt.UnregisterContextExtractor("plan")
+	if got := mt.ContextAttributes(ctx); !reflect.DeepEqual(got, []mt.Attribute{mt.Attr(mt.TenantIDAttr, "acme")}) {
+		t.Fatalf("expected only tenant_id after removing the extractor, got %v", got)
+	}
+}
@@ -3,6 +3,8 @@ package metrics
 import (
 	"context"
 	"sync/atomic"
+
+	"github.com/totvs/go-sdk/trace"
 )
 
 // Public types and interfaces
@@ -34,7 +36,8 @@ type Counter interface {
 	Inc(ctx context.Context, attrs ...Attribute)
 }
 
-// Gauge is a metric that can increase or decrease.
+// Gauge is a metric that can increase or decrease. Set replaces the current
+// value of the attribute set and Add changes it by incr (starting at zero).
 type Gauge interface {
 	Set(ctx context.Context, value float64, attrs ...Attribute)
 	Add(ctx context.Context, incr float64, attrs ...Attribute)
@@ -50,9 +53,15 @@ type Histogram interface {
 type MetricsFacade interface {
 	WithAttributes(attrs ...Attribute) MetricsFacade
 	WithAttributesFromContext(ctx context.Context) MetricsFacade
-	GetOrCreateCounter(name string, metricType MetricType, metricClass MetricClass) Counter
-	GetOrCreateGauge(name string, metricType MetricType, metricClass MetricClass) Gauge
-	GetOrCreateHistogram(name string, metricType MetricType, metricClass MetricClass) Histogram
+	GetOrCreateCounter(name string, metricType MetricType, metricClass MetricClass, opts ...MetricOption) Counter
+	GetOrCreateGauge(name string, metricType MetricType, metricClass MetricClass, opts ...MetricOption) Gauge
+	GetOrCreateHistogram(name string, metricType MetricType, metricClass MetricClass, opts ...MetricOption) Histogram
+	// RegisterObservableGauge registers a gauge whose values are sampled by
+	// callback at collection time (queue depth, pool size, cache entries).
+	RegisterObservableGauge(name string, metricType MetricType, metricClass MetricClass, callback ObservableCallback, opts ...MetricOption) (Registration, error)
+	// RegisterObservableCounter registers a monotonic counter whose callback
+	// observes the cumulative total of each attribute set.
+	RegisterObservableCounter(name string, metricType MetricType, metricClass MetricClass, callback ObservableCallback, opts ...MetricOption) (Registration, error)
 }
 
 // Public helper functions
@@ -69,6 +78,9 @@ type ctxKey string
 
 const metricsKey ctxKey = "metrics"
 
+// trace.Detach keeps the metrics facade stored in the context.
+func init() { trace.RegisterDetachKey(metricsKey) }
+
 // globalMetrics stores the package-level metrics in an atomic.Value to make
 // reads/writes safe for concurrent access. Use SetGlobal/GetGlobal to access.
 var globalMetrics atomic.Value
@@ -101,18 +113,18 @@ func GetGlobal() MetricsFacade {
 // Package-level helpers for global metrics
 
 // NewCounter creates a Counter using the global metrics.
-func NewCounter(name string, metricType MetricType, metricClass MetricClass) Counter {
-	return GetGlobal().GetOrCreateCounter(name, metricType, metricClass)
+func NewCounter(name string, metricType MetricType, metricClass MetricClass, opts ...MetricOption) Counter {
+	return GetGlobal().GetOrCreateCounter(name, metricType, metricClass, opts...)
 }
 
 // NewGauge creates a Gauge using the global metrics.
-func NewGauge(name string, metricType MetricType, metricClass MetricClass) Gauge {
-	return GetGlobal().GetOrCreateGauge(name, metricType, metricClass)
+func NewGauge(name string, metricType MetricType, metricClass MetricClass, opts ...MetricOption) Gauge {
+	return GetGlobal().GetOrCreateGauge(name, metricType, metricClass, opts...)
 }
 
 // NewHistogram creates a Histogram using the global metrics.
-func NewHistogram(name string, metricType MetricType, metricClass MetricClass) Histogram {
-	return GetGlobal().GetOrCreateHistogram(name, metricType, metricClass)
+func NewHistogram(name string, metricType MetricType, metricClass MetricClass, opts ...MetricOption) Histogram {
+	return GetGlobal().GetOrCreateHistogram(name, metricType, metricClass, opts...)
 }
 
 // Context functions
@@ -165,12 +177,18 @@ type nopMetrics struct{}
 
 func (nopMetrics) WithAttributes(attrs ...Attribute) MetricsFacade             { return nopMetrics{} }
 func (nopMetrics) WithAttributesFromContext(ctx context.Context) MetricsFacade { return nopMetrics{} }
-func (nopMetrics) GetOrCreateCounter(name string, metricType MetricType, metricClass MetricClass) Counter {
+func (nopMetrics) GetOrCreateCounter(name string, metricType MetricType, metricClass MetricClass, opts ...MetricOption) Counter {
 	return nopCounter{}
 }
-func (nopMetrics) GetOrCreateGauge(name string, metricType MetricType, metricClass MetricClass) Gauge {
+func (nopMetrics) GetOrCreateGauge(name string, metricType MetricType, metricClass MetricClass, opts ...MetricOption) Gauge {
 	return nopGauge{}
 }
-func (nopMetrics) GetOrCreateHistogram(name string, metricType MetricType, metricClass MetricClass) Histogram {
+func (nopMetrics) GetOrCreateHistogram(name string, metricType MetricType, metricClass MetricClass, opts ...MetricOption) Histogram {
 	return nopHistogram{}
 }
+func (nopMetrics) RegisterObservableGauge(name string, metricType MetricType, metricClass MetricClass, callback ObservableCallback, opts ...MetricOption) (Registration, error) {
+	return nopRegistration{}, nil
+}
+func (nopMetrics) RegisterObservableCounter(name string, metricType MetricType, metricClass MetricClass, callback ObservableCallback, opts ...MetricOption) (Registration, error) {
+	return nopRegistration{}, nil
+}
@@ -2,6 +2,9 @@ package metrics_test
 
 import (
 	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
 	"sync"
 	"testing"
 
@@ -129,7 +132,7 @@ func TestWithAttributesFromContext(t *testing.T) {
 
 	ctx := context.Background()
 
-	// Test extracting attributes from context (currently returns self)
+	// Test extracting attributes from context (no values in the context: returns self)
 	metricsWithCtx := setup.Metrics.WithAttributesFromContext(ctx)
 	if metricsWithCtx == nil {
 		t.Fatal("expected metrics with context attributes")
@@ -237,4 +240,34 @@ func TestNoOpFallbacks(t *testing.T) {
 
 	histogram := global.GetOrCreateHistogram("noop_histogram", mt.MetricTypeTech, mt.MetricClassService)
 	histogram.Record(ctx, 0.1, mt.Attr("test", "noop"))
+
+	reg, err := global.RegisterObservableGauge("noop_observable", mt.MetricTypeTech, mt.MetricClassInstance,
+		func(ctx context.Context, o mt.Observer) error { return nil })
+	if err != nil || reg.Unregister() != nil {
+		t.Fatalf("expected a no-op registration, got %v", err)
+	}
+}
+
+func TestMetricDescriptorsInPrometheus(t *testing.T) {
+	setup, err := adapter.NewPrometheusMetrics("descriptor-service")
+	if err != nil {
+		t.Fatalf("failed to create metrics: %v", err)
+	}
+	defer setup.Shutdown()
+
+	ctx := context.Background()
+	setup.Metrics.GetOrCreateHistogram("checkout_latency", mt.MetricTypeTech, mt.MetricClassService,
+		mt.WithDescription("Checkout latency."), mt.WithUnit("ms"), mt.WithBuckets(5, 10, 25)).Record(ctx, 7)
+
+	rec := httptest.NewRecorder()
+	setup.Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	body := rec.Body.String()
+	for _, want := range []string{
+		"# HELP checkout_latency_milliseconds Checkout latency.",
+		`checkout_latency_milliseconds_bucket{metric_class="service",metric_type="tech",otel_scope_name="descriptor-service",otel_scope_schema_url="",otel_scope_version="",le="10"} 1`,
+	} {
+		if !strings.Contains(body, want) {
+			t.Fatalf("expected %q in:\n%s", want, body)
+		}
+	}
 }
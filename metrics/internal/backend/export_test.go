@@ -0,0 +1,4 @@
+package backend
+
+// MaxGaugeSeries exposes the number of gauge values tracked per meter.
+const MaxGaugeSeries = maxGaugeSeries
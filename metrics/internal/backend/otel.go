@@ -3,12 +3,16 @@ package backend
 import (
 	"context"
 	"fmt"
+	"reflect"
 	"sync"
+	"sync/atomic"
 
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/metric"
+	oteltrace "go.opentelemetry.io/otel/trace"
 
 	mt "github.com/totvs/go-sdk/metrics"
+	"github.com/totvs/go-sdk/trace"
 )
 
 // otelCounter wraps an OpenTelemetry counter
@@ -29,28 +33,103 @@ func (c *otelCounter) Inc(ctx context.Context, attrs ...mt.Attribute) {
 	c.Add(ctx, 1, attrs...)
 }
 
-// otelGauge wraps an OpenTelemetry gauge
+// otelGauge wraps an OpenTelemetry gauge. OpenTelemetry gauges only record
+// absolute values, so the current value of each attribute set is tracked in
+// the gaugeState of the meter and Add records the updated total.
 type otelGauge struct {
-	gauge metric.Float64Gauge
-	attrs []attribute.KeyValue
+	gauge  metric.Float64Gauge
+	name   string
+	attrs  []attribute.KeyValue
+	values *gaugeState
 }
 
-func (g *otelGauge) Set(ctx context.Context, value float64, attrs ...mt.Attribute) {
-	if g.gauge == nil {
-		return // no-op if gauge creation failed
+// maxGaugeSeries bounds the number of gauge values tracked per meter.
+const maxGaugeSeries = 2000
+
+// gaugeStates holds the gaugeState of each meter, so every facade created
+// from the same meter adds to the same values.
+var gaugeStates sync.Map // map[metric.Meter]*gaugeState
+
+// gaugeState tracks the current value of each gauge series of a meter. Values
+// are kept for the life of the process (a gauge has no notion of a finished
+// series), so at most maxGaugeSeries are tracked: past that limit Set on a new
+// series is recorded without being tracked and Add on a new series is dropped.
+type gaugeState struct {
+	values sync.Map // map[gaugeKey]*gaugeValue
+	n      atomic.Int64
+}
+
+// gaugeKey identifies the value of a gauge for one attribute set.
+type gaugeKey struct {
+	name string
+	set  attribute.Distinct
+}
+
+type gaugeValue struct {
+	mu sync.Mutex
+	v  float64
+}
+
+// gaugeStateFor returns the gaugeState shared by the facades of meter. A
+// meter that cannot be used as a map key gets a state of its own.
+func gaugeStateFor(meter metric.Meter) *gaugeState {
+	if meter == nil || !reflect.TypeOf(meter).Comparable() {
+		return &gaugeState{}
+	}
+	if st, ok := gaugeStates.Load(meter); ok {
+		return st.(*gaugeState)
 	}
-	combinedAttrs := combineAttributes(g.attrs, attrs)
-	g.gauge.Record(ctx, value, metric.WithAttributes(combinedAttrs...))
+	st, _ := gaugeStates.LoadOrStore(meter, &gaugeState{})
+	return st.(*gaugeState)
+}
+
+// lookup returns the tracked value of key, adding it when the limit allows;
+// nil means the series is not tracked.
+func (s *gaugeState) lookup(key gaugeKey) *gaugeValue {
+	if v, ok := s.values.Load(key); ok {
+		return v.(*gaugeValue)
+	}
+	if s.n.Add(1) > maxGaugeSeries {
+		s.n.Add(-1)
+		return nil
+	}
+	v, loaded := s.values.LoadOrStore(key, &gaugeValue{})
+	if loaded {
+		s.n.Add(-1)
+	}
+	return v.(*gaugeValue)
+}
+
+func (g *otelGauge) Set(ctx context.Context, value float64, attrs ...mt.Attribute) {
+	g.update(ctx, attrs, value, false)
 }
 
 func (g *otelGauge) Add(ctx context.Context, incr float64, attrs ...mt.Attribute) {
+	g.update(ctx, attrs, incr, true)
+}
+
+// update sets (or adds v to) the tracked value of the attribute set and
+// records the result. The value is recorded while holding its lock, so
+// concurrent Set/Add calls are reported in the same order they are applied.
+func (g *otelGauge) update(ctx context.Context, attrs []mt.Attribute, v float64, add bool) {
 	if g.gauge == nil {
 		return // no-op if gauge creation failed
 	}
-	// Note: OpenTelemetry gauges don't have native Add, so we'll need to track state
-	// For now, we'll just record the increment as-is
-	combinedAttrs := combineAttributes(g.attrs, attrs)
-	g.gauge.Record(ctx, incr, metric.WithAttributes(combinedAttrs...))
+	set := attribute.NewSet(combineAttributes(g.attrs, attrs)...)
+	gv := g.values.lookup(gaugeKey{name: g.name, set: set.Equivalent()})
+	if gv == nil {
+		if !add {
+			g.gauge.Record(ctx, v, metric.WithAttributeSet(set))
+		}
+		return
+	}
+	gv.mu.Lock()
+	defer gv.mu.Unlock()
+	if add {
+		v += gv.v
+	}
+	gv.v = v
+	g.gauge.Record(ctx, gv.v, metric.WithAttributeSet(set))
 }
 
 // otelHistogram wraps an OpenTelemetry histogram
@@ -64,43 +143,96 @@ func (h *otelHistogram) Record(ctx context.Context, value float64, attrs ...mt.A
 		return // no-op if histogram creation failed
 	}
 	combinedAttrs := combineAttributes(h.attrs, attrs)
-	h.histogram.Record(ctx, value, metric.WithAttributes(combinedAttrs...))
+	h.histogram.Record(exemplarContext(ctx), value, metric.WithAttributes(combinedAttrs...))
+}
+
+// exemplarContext makes the trace of ctx visible to the OpenTelemetry SDK,
+// which samples exemplars from the span context of the recording context.
+// When there is no active span, the W3C trace and span ids stored by the
+// trace package (the ones logged as trace_id/span_id) are used, so latency
+// outliers link to the request logs and traces.
+func exemplarContext(ctx context.Context) context.Context {
+	if ctx == nil || oteltrace.SpanContextFromContext(ctx).IsValid() {
+		return ctx
+	}
+	p, ok := trace.TraceParentFromContext(ctx)
+	if !ok {
+		return ctx
+	}
+	tid, _ := oteltrace.TraceIDFromHex(p.TraceID)
+	sid, _ := oteltrace.SpanIDFromHex(p.SpanID)
+	cfg := oteltrace.SpanContextConfig{TraceID: tid, SpanID: sid}
+	if p.Sampled {
+		cfg.TraceFlags = oteltrace.FlagsSampled
+	}
+	return oteltrace.ContextWithSpanContext(ctx, oteltrace.NewSpanContext(cfg))
+}
+
+// instruments caches the instruments of a root facade (see newMetrics). The
+// cached wrappers carry the attributes of that facade; derived facades share
+// the cache and add their own attributes to the returned wrappers.
+type instruments struct {
+	attrs       []attribute.KeyValue
+	views       *Views      // nil when the provider does not install a Views
+	gaugeValues *gaugeState // shared by the facades of the meter
+	counters    sync.Map    // map[string]*otelCounter
+	gauges      sync.Map    // map[string]*otelGauge
+	histograms  sync.Map    // map[string]*otelHistogram
 }
 
 // implMetrics is the concrete metrics implementation based on OpenTelemetry.
 type implMetrics struct {
-	meter      metric.Meter
-	attrs      []attribute.KeyValue
-	counters   sync.Map // map[string]*otelCounter
-	gauges     sync.Map // map[string]*otelGauge
-	histograms sync.Map // map[string]*otelHistogram
+	meter metric.Meter
+	attrs []attribute.KeyValue // all the attributes of the facade
+	extra []attribute.KeyValue // attributes not carried by the cached instruments
+	cache *instruments
 }
 
-// newMetrics creates a metrics implementation with the provided meter and attributes.
-func newMetrics(meter metric.Meter, attrs []attribute.KeyValue) mt.MetricsFacade {
+// newMetrics creates a metrics implementation with the provided meter, views and attributes.
+func newMetrics(meter metric.Meter, views *Views, attrs []attribute.KeyValue) mt.MetricsFacade {
 	return &implMetrics{
 		meter: meter,
 		attrs: attrs,
+		cache: &instruments{attrs: attrs, views: views, gaugeValues: gaugeStateFor(meter)},
 	}
 }
 
+// WithAttributes returns a facade sharing the instruments of m, so deriving
+// a facade per request (see WithAttributesFromContext) does not create them
+// again.
 func (m *implMetrics) WithAttributes(attrs ...mt.Attribute) mt.MetricsFacade {
-	combinedAttrs := combineAttributes(m.attrs, attrs)
 	return &implMetrics{
 		meter: m.meter,
-		attrs: combinedAttrs,
+		attrs: combineAttributes(m.attrs, attrs),
+		extra: combineAttributes(m.extra, attrs),
+		cache: m.cache,
+	}
+}
+
+// bind returns base followed by the attributes of m missing from the cached
+// instruments, or base itself when there are none.
+func (m *implMetrics) bind(base []attribute.KeyValue) ([]attribute.KeyValue, bool) {
+	if len(m.extra) == 0 {
+		return base, false
 	}
+	attrs := make([]attribute.KeyValue, 0, len(base)+len(m.extra))
+	return append(append(attrs, base...), m.extra...), true
 }
 
+// WithAttributesFromContext returns a facade whose metrics also carry the
+// attributes reported by the context extractors (see
+// mt.RegisterContextExtractor); m itself is returned when there are none.
 func (m *implMetrics) WithAttributesFromContext(ctx context.Context) mt.MetricsFacade {
-	// Extract trace ID if available (similar to log package)
-	// For now, just return self as we don't have trace integration yet
-	return m
+	attrs := mt.ContextAttributes(ctx)
+	if len(attrs) == 0 {
+		return m
+	}
+	return m.WithAttributes(attrs...)
 }
 
 // buildMetricAttrs creates attributes with metric_type and metric_class
-func (m *implMetrics) buildMetricAttrs(metricType mt.MetricType, metricClass mt.MetricClass) []attribute.KeyValue {
-	return append(m.attrs,
+func buildMetricAttrs(attrs []attribute.KeyValue, metricType mt.MetricType, metricClass mt.MetricClass) []attribute.KeyValue {
+	return append(attrs[:len(attrs):len(attrs)],
 		attribute.String("metric_type", string(metricType)),
 		attribute.String("metric_class", string(metricClass)),
 	)
@@ -123,8 +255,7 @@ func getOrCreate[T any](cache *sync.Map, key string, creator func() (T, error))
 	// Cache miss: create new metric instance lazily
 	metric, err := creator()
 	if err != nil {
-		var zero T  // Initialize zero value of type T
-		return zero // Return no-op metric on error
+		return metric // Return the no-op metric built by creator, uncached
 	}
 
 	// Store new instance in cache for future calls (singleton pattern)
@@ -132,46 +263,146 @@ func getOrCreate[T any](cache *sync.Map, key string, creator func() (T, error))
 	return metric
 }
 
-func (m *implMetrics) GetOrCreateCounter(name string, metricType mt.MetricType, metricClass mt.MetricClass) mt.Counter {
+// instrumentOptions converts the descriptors of cfg into instrument options.
+func instrumentOptions(cfg mt.MetricConfig) []metric.InstrumentOption {
+	var opts []metric.InstrumentOption
+	if cfg.Description != "" {
+		opts = append(opts, metric.WithDescription(cfg.Description))
+	}
+	if cfg.Unit != "" {
+		opts = append(opts, metric.WithUnit(cfg.Unit))
+	}
+	return opts
+}
+
+func (m *implMetrics) GetOrCreateCounter(name string, metricType mt.MetricType, metricClass mt.MetricClass, opts ...mt.MetricOption) mt.Counter {
 	key := buildKey("counter", name, metricType, metricClass)
-	return getOrCreate(&m.counters, key, func() (*otelCounter, error) {
-		counter, err := m.meter.Int64Counter(name)
+	c := getOrCreate(&m.cache.counters, key, func() (*otelCounter, error) {
+		var counterOpts []metric.Int64CounterOption
+		for _, o := range instrumentOptions(mt.NewMetricConfig(opts...)) {
+			counterOpts = append(counterOpts, o)
+		}
+		counter, err := m.meter.Int64Counter(name, counterOpts...)
 		if err != nil {
 			return &otelCounter{}, err
 		}
 		return &otelCounter{
 			counter: counter,
-			attrs:   m.buildMetricAttrs(metricType, metricClass),
+			attrs:   buildMetricAttrs(m.cache.attrs, metricType, metricClass),
 		}, nil
 	})
+	if attrs, ok := m.bind(c.attrs); ok {
+		return &otelCounter{counter: c.counter, attrs: attrs}
+	}
+	return c
 }
 
-func (m *implMetrics) GetOrCreateGauge(name string, metricType mt.MetricType, metricClass mt.MetricClass) mt.Gauge {
+func (m *implMetrics) GetOrCreateGauge(name string, metricType mt.MetricType, metricClass mt.MetricClass, opts ...mt.MetricOption) mt.Gauge {
 	key := buildKey("gauge", name, metricType, metricClass)
-	return getOrCreate(&m.gauges, key, func() (*otelGauge, error) {
-		gauge, err := m.meter.Float64Gauge(name)
+	g := getOrCreate(&m.cache.gauges, key, func() (*otelGauge, error) {
+		var gaugeOpts []metric.Float64GaugeOption
+		for _, o := range instrumentOptions(mt.NewMetricConfig(opts...)) {
+			gaugeOpts = append(gaugeOpts, o)
+		}
+		gauge, err := m.meter.Float64Gauge(name, gaugeOpts...)
 		if err != nil {
 			return &otelGauge{}, err
 		}
 		return &otelGauge{
-			gauge: gauge,
-			attrs: m.buildMetricAttrs(metricType, metricClass),
+			gauge:  gauge,
+			name:   name,
+			attrs:  buildMetricAttrs(m.cache.attrs, metricType, metricClass),
+			values: m.cache.gaugeValues,
 		}, nil
 	})
+	if attrs, ok := m.bind(g.attrs); ok {
+		return &otelGauge{gauge: g.gauge, name: g.name, attrs: attrs, values: g.values}
+	}
+	return g
 }
 
-func (m *implMetrics) GetOrCreateHistogram(name string, metricType mt.MetricType, metricClass mt.MetricClass) mt.Histogram {
+func (m *implMetrics) GetOrCreateHistogram(name string, metricType mt.MetricType, metricClass mt.MetricClass, opts ...mt.MetricOption) mt.Histogram {
 	key := buildKey("histogram", name, metricType, metricClass)
-	return getOrCreate(&m.histograms, key, func() (*otelHistogram, error) {
-		histogram, err := m.meter.Float64Histogram(name)
+	h := getOrCreate(&m.cache.histograms, key, func() (*otelHistogram, error) {
+		cfg := mt.NewMetricConfig(opts...)
+		var histogramOpts []metric.Float64HistogramOption
+		for _, o := range instrumentOptions(cfg) {
+			histogramOpts = append(histogramOpts, o)
+		}
+		if cfg.Exponential != nil && m.cache.views != nil {
+			// Registered before the instrument is created, when the SDK
+			// resolves its views (see Views.View).
+			m.cache.views.exponential.Store(name, *cfg.Exponential)
+		} else if len(cfg.Buckets) > 0 {
+			histogramOpts = append(histogramOpts, metric.WithExplicitBucketBoundaries(cfg.Buckets...))
+		}
+		histogram, err := m.meter.Float64Histogram(name, histogramOpts...)
 		if err != nil {
 			return &otelHistogram{}, err
 		}
 		return &otelHistogram{
 			histogram: histogram,
-			attrs:     m.buildMetricAttrs(metricType, metricClass),
+			attrs:     buildMetricAttrs(m.cache.attrs, metricType, metricClass),
 		}, nil
 	})
+	if attrs, ok := m.bind(h.attrs); ok {
+		return &otelHistogram{histogram: h.histogram, attrs: attrs}
+	}
+	return h
+}
+
+func (m *implMetrics) RegisterObservableGauge(name string, metricType mt.MetricType, metricClass mt.MetricClass, callback mt.ObservableCallback, opts ...mt.MetricOption) (mt.Registration, error) {
+	if callback == nil {
+		return nil, mt.ErrNilCallback
+	}
+	var gaugeOpts []metric.Float64ObservableGaugeOption
+	for _, o := range instrumentOptions(mt.NewMetricConfig(opts...)) {
+		gaugeOpts = append(gaugeOpts, o)
+	}
+	gauge, err := m.meter.Float64ObservableGauge(name, gaugeOpts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create observable gauge %s: %w", name, err)
+	}
+	return m.registerCallback(gauge, metricType, metricClass, callback)
+}
+
+func (m *implMetrics) RegisterObservableCounter(name string, metricType mt.MetricType, metricClass mt.MetricClass, callback mt.ObservableCallback, opts ...mt.MetricOption) (mt.Registration, error) {
+	if callback == nil {
+		return nil, mt.ErrNilCallback
+	}
+	var counterOpts []metric.Float64ObservableCounterOption
+	for _, o := range instrumentOptions(mt.NewMetricConfig(opts...)) {
+		counterOpts = append(counterOpts, o)
+	}
+	counter, err := m.meter.Float64ObservableCounter(name, counterOpts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create observable counter %s: %w", name, err)
+	}
+	return m.registerCallback(counter, metricType, metricClass, callback)
+}
+
+// registerCallback registers callback for the observable instrument with the
+// OpenTelemetry meter; the returned metric.Registration is the unregister handle.
+func (m *implMetrics) registerCallback(inst metric.Float64Observable, metricType mt.MetricType, metricClass mt.MetricClass, callback mt.ObservableCallback) (mt.Registration, error) {
+	attrs := buildMetricAttrs(m.attrs, metricType, metricClass)
+	reg, err := m.meter.RegisterCallback(func(ctx context.Context, o metric.Observer) error {
+		return callback(ctx, &otelObserver{observer: o, inst: inst, attrs: attrs})
+	}, inst)
+	if err != nil {
+		return nil, fmt.Errorf("failed to register callback: %w", err)
+	}
+	return reg, nil
+}
+
+// otelObserver adapts metric.Observer to mt.Observer for one instrument.
+type otelObserver struct {
+	observer metric.Observer
+	inst     metric.Float64Observable
+	attrs    []attribute.KeyValue
+}
+
+func (o *otelObserver) Observe(value float64, attrs ...mt.Attribute) {
+	o.observer.ObserveFloat64(o.inst, value, metric.WithAttributes(combineAttributes(o.attrs, attrs)...))
 }
 
 // convertAttribute converts a single mt.Attribute to OTEL attribute.KeyValue
@@ -206,21 +437,29 @@ func combineAttributes(base []attribute.KeyValue, additional []mt.Attribute) []a
 
 // NewMetrics creates a MetricsFacade based on OpenTelemetry with the provided meter.
 func NewMetrics(meter metric.Meter) mt.MetricsFacade {
-	return newMetrics(meter, nil)
+	return newMetrics(meter, nil, nil)
 }
 
 // NewMetricsWithProvider creates a MetricsFacade using a custom MeterProvider.
 func NewMetricsWithProvider(provider metric.MeterProvider, serviceName string) mt.MetricsFacade {
 	meter := provider.Meter(serviceName)
-	return newMetrics(meter, nil)
+	return newMetrics(meter, nil, nil)
 }
 
 // NewMetricsWithAttributes creates a MetricsFacade with base attributes that will be
 // applied to all metrics created from this instance.
 func NewMetricsWithAttributes(meter metric.Meter, attrs []mt.Attribute) mt.MetricsFacade {
+	return NewMetricsWithViews(meter, nil, attrs)
+}
+
+// NewMetricsWithViews creates a MetricsFacade with base attributes whose
+// metric options needing a view (exponential histograms) are recorded in
+// views. The MeterProvider of meter must install views.View; with nil views
+// exponential histograms fall back to explicit buckets.
+func NewMetricsWithViews(meter metric.Meter, views *Views, attrs []mt.Attribute) mt.MetricsFacade {
 	otelAttrs := make([]attribute.KeyValue, len(attrs))
 	for i, attr := range attrs {
 		otelAttrs[i] = convertAttribute(attr)
 	}
-	return newMetrics(meter, otelAttrs)
+	return newMetrics(meter, views, otelAttrs)
 }
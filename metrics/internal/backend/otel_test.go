@@ -2,12 +2,21 @@ package backend_test
 
 import (
 	"context"
+	"encoding/hex"
+	"errors"
+	"reflect"
+	"strings"
+	"sync"
 	"testing"
 
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
 	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
 
 	mt "github.com/totvs/go-sdk/metrics"
 	backend "github.com/totvs/go-sdk/metrics/internal/backend"
+	"github.com/totvs/go-sdk/trace"
 )
 
 func TestNewMetrics(t *testing.T) {
@@ -85,6 +94,130 @@ func TestGaugeOperations(t *testing.T) {
 	gauge.Add(ctx, -5.0, mt.Attr("operation", "decrement"))
 }
 
+func TestGaugeAddIsCumulative(t *testing.T) {
+	reader := sdkmetric.NewManualReader()
+	provider := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+	metrics := backend.NewMetrics(provider.Meter("test-service"))
+	gauge := metrics.GetOrCreateGauge("in_flight", mt.MetricTypeTech, mt.MetricClassService)
+	ctx := context.Background()
+
+	gauge.Add(ctx, 1, mt.Attr("pool", "a"))
+	gauge.Add(ctx, 1, mt.Attr("pool", "a"))
+	gauge.Add(ctx, -0.5, mt.Attr("pool", "a"))
+	gauge.Set(ctx, 10, mt.Attr("pool", "b"))
+	gauge.Add(ctx, 2, mt.Attr("pool", "b"))
+
+	// Facades derived per request share the tracked values.
+	reqCtx := mt.ContextWithRoute(ctx, "/jobs")
+	metrics.WithAttributesFromContext(reqCtx).
+		GetOrCreateGauge("in_flight", mt.MetricTypeTech, mt.MetricClassService).Add(ctx, 1)
+	metrics.WithAttributesFromContext(reqCtx).
+		GetOrCreateGauge("in_flight", mt.MetricTypeTech, mt.MetricClassService).Add(ctx, 1)
+
+	want := map[string]float64{"pool=a": 1.5, "pool=b": 12, "route=/jobs": 2}
+	if got := gaugeValues(t, reader, "in_flight"); !reflect.DeepEqual(got, want) {
+		t.Fatalf("expected %v, got %v", want, got)
+	}
+}
+
+func TestGaugeConcurrentAddAndSet(t *testing.T) {
+	reader := sdkmetric.NewManualReader()
+	provider := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+	gauge := backend.NewMetrics(provider.Meter("test-service")).
+		GetOrCreateGauge("workers", mt.MetricTypeTech, mt.MetricClassService)
+	ctx := context.Background()
+
+	const goroutines, iterations = 8, 500
+	sets := []string{"a", "b", "c"}
+	for _, s := range sets {
+		gauge.Set(ctx, 100, mt.Attr("pool", s))
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < goroutines; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < iterations; j++ {
+				for _, s := range sets {
+					gauge.Add(ctx, 1, mt.Attr("pool", s))
+					gauge.Add(ctx, -1, mt.Attr("pool", s))
+				}
+				gauge.Add(ctx, 1, mt.Attr("pool", "a"))
+				gauge.Set(ctx, 7, mt.Attr("pool", "d"))
+			}
+		}()
+	}
+	wg.Wait()
+
+	want := map[string]float64{"pool=a": 100 + goroutines*iterations, "pool=b": 100, "pool=c": 100, "pool=d": 7}
+	if got := gaugeValues(t, reader, "workers"); !reflect.DeepEqual(got, want) {
+		t.Fatalf("expected %v, got %v", want, got)
+	}
+}
+
+func TestGaugeAddSharedByFacadesOfAMeter(t *testing.T) {
+	reader := sdkmetric.NewManualReader()
+	meter := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader)).Meter("test-service")
+	ctx := context.Background()
+
+	for i := 0; i < 2; i++ {
+		backend.NewMetrics(meter).GetOrCreateGauge("in_flight", mt.MetricTypeTech, mt.MetricClassService).
+			Add(ctx, 1, mt.Attr("pool", "a"))
+	}
+
+	want := map[string]float64{"pool=a": 2}
+	if got := gaugeValues(t, reader, "in_flight"); !reflect.DeepEqual(got, want) {
+		t.Fatalf("expected %v, got %v", want, got)
+	}
+}
+
+func TestGaugeTrackedSeriesAreBounded(t *testing.T) {
+	reader := sdkmetric.NewManualReader()
+	provider := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+	gauge := backend.NewMetrics(provider.Meter("test-service")).
+		GetOrCreateGauge("sessions", mt.MetricTypeTech, mt.MetricClassService)
+	ctx := context.Background()
+
+	for i := 0; i < backend.MaxGaugeSeries; i++ {
+		gauge.Add(ctx, 1, mt.Attr("id", i))
+	}
+	// Past the limit Add on a new series is dropped and Set is not tracked.
+	gauge.Add(ctx, 1, mt.Attr("id", "over"))
+	gauge.Set(ctx, 5, mt.Attr("id", "set"))
+	gauge.Add(ctx, 1, mt.Attr("id", "set"))
+	// Tracked series keep adding up.
+	gauge.Add(ctx, 1, mt.Attr("id", 0))
+
+	got := gaugeValues(t, reader, "sessions")
+	if len(got) != backend.MaxGaugeSeries+1 {
+		t.Fatalf("expected %d series, got %d", backend.MaxGaugeSeries+1, len(got))
+	}
+	if _, ok := got["id=over"]; ok {
+		t.Fatalf("expected Add on an untracked series to be dropped")
+	}
+	if got["id=set"] != 5 || got["id=0"] != 2 {
+		t.Fatalf("expected id=set 5 and id=0 2, got %v and %v", got["id=set"], got["id=0"])
+	}
+}
+
+// gaugeValues returns the collected values of the gauge name keyed by its
+// "key=value" attributes, ignoring metric_type and metric_class.
+func gaugeValues(t *testing.T, reader sdkmetric.Reader, name string) map[string]float64 {
+	t.Helper()
+	out := map[string]float64{}
+	for _, dp := range collect(t, reader, name).Data.(metricdata.Gauge[float64]).DataPoints {
+		var labels []string
+		for _, kv := range dp.Attributes.ToSlice() {
+			if kv.Key != "metric_type" && kv.Key != "metric_class" {
+				labels = append(labels, string(kv.Key)+"="+kv.Value.Emit())
+			}
+		}
+		out[strings.Join(labels, ",")] = dp.Value
+	}
+	return out
+}
+
 func TestHistogramOperations(t *testing.T) {
 	provider := sdkmetric.NewMeterProvider()
 	meter := provider.Meter("test-service")
@@ -108,6 +241,125 @@ func TestHistogramOperations(t *testing.T) {
 	}
 }
 
+func TestMetricDescriptors(t *testing.T) {
+	reader := sdkmetric.NewManualReader()
+	views := backend.NewViews()
+	provider := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader), sdkmetric.WithView(views.View))
+	metrics := backend.NewMetricsWithViews(provider.Meter("test-service"), views, nil)
+	ctx := context.Background()
+
+	metrics.GetOrCreateCounter("jobs", mt.MetricTypeTech, mt.MetricClassService,
+		mt.WithDescription("Processed jobs."), mt.WithUnit("{job}")).Inc(ctx)
+	metrics.GetOrCreateHistogram("latency", mt.MetricTypeTech, mt.MetricClassService,
+		mt.WithUnit("ms"), mt.WithBuckets(5, 10, 50)).Record(ctx, 7)
+	metrics.GetOrCreateHistogram("payload", mt.MetricTypeTech, mt.MetricClassService,
+		mt.WithExponentialBuckets(20, 0), mt.WithBuckets(1, 2)).Record(ctx, 300)
+
+	jobs := collect(t, reader, "jobs")
+	if jobs.Description != "Processed jobs." || jobs.Unit != "{job}" {
+		t.Fatalf("unexpected counter descriptors: %q %q", jobs.Description, jobs.Unit)
+	}
+
+	latency := collect(t, reader, "latency")
+	bounds := latency.Data.(metricdata.Histogram[float64]).DataPoints[0].Bounds
+	if latency.Unit != "ms" || !reflect.DeepEqual(bounds, []float64{5, 10, 50}) {
+		t.Fatalf("unexpected histogram unit %q or bounds %v", latency.Unit, bounds)
+	}
+
+	exp, ok := collect(t, reader, "payload").Data.(metricdata.ExponentialHistogram[float64])
+	if !ok {
+		t.Fatal("expected an exponential histogram")
+	}
+	if dp := exp.DataPoints[0]; dp.Count != 1 || dp.Sum != 300 {
+		t.Fatalf("unexpected exponential data point: %+v", dp)
+	}
+
+	// The views of a provider do not leak into another one.
+	other := sdkmetric.NewManualReader()
+	views = backend.NewViews()
+	provider = sdkmetric.NewMeterProvider(sdkmetric.WithReader(other), sdkmetric.WithView(views.View))
+	backend.NewMetricsWithViews(provider.Meter("test-service"), views, nil).GetOrCreateHistogram("payload",
+		mt.MetricTypeTech, mt.MetricClassService, mt.WithBuckets(1, 2)).Record(ctx, 300)
+	if _, ok := collect(t, other, "payload").Data.(metricdata.Histogram[float64]); !ok {
+		t.Fatal("expected an explicit bucket histogram on the other provider")
+	}
+}
+
+func TestObservableMetrics(t *testing.T) {
+	reader := sdkmetric.NewManualReader()
+	provider := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+	metrics := backend.NewMetrics(provider.Meter("test-service")).WithAttributes(mt.Attr("app", "worker"))
+
+	depth := map[string]float64{"orders": 3, "emails": 12}
+	gaugeReg, err := metrics.RegisterObservableGauge("queue_depth", mt.MetricTypeTech, mt.MetricClassInstance,
+		func(ctx context.Context, o mt.Observer) error {
+			for queue, n := range depth {
+				o.Observe(n, mt.Attr("queue", queue))
+			}
+			return nil
+		}, mt.WithDescription("Messages waiting."))
+	if err != nil {
+		t.Fatalf("RegisterObservableGauge: %v", err)
+	}
+
+	var hits float64
+	counterReg, err := metrics.RegisterObservableCounter("cache_hits", mt.MetricTypeTech, mt.MetricClassInstance,
+		func(ctx context.Context, o mt.Observer) error {
+			hits += 5
+			o.Observe(hits)
+			return nil
+		})
+	if err != nil {
+		t.Fatalf("RegisterObservableCounter: %v", err)
+	}
+
+	queue := collect(t, reader, "queue_depth")
+	if queue.Description != "Messages waiting." {
+		t.Fatalf("unexpected description %q", queue.Description)
+	}
+	got := map[string]float64{}
+	for _, dp := range queue.Data.(metricdata.Gauge[float64]).DataPoints {
+		q, _ := dp.Attributes.Value("queue")
+		if v, _ := dp.Attributes.Value("app"); v.AsString() != "worker" {
+			t.Fatalf("expected the facade attributes, got %v", dp.Attributes.ToSlice())
+		}
+		got[q.AsString()] = dp.Value
+	}
+	if !reflect.DeepEqual(got, depth) {
+		t.Fatalf("expected %v, got %v", depth, got)
+	}
+
+	// Values are sampled on every collection.
+	depth["orders"] = 0
+	sum := collect(t, reader, "cache_hits").Data.(metricdata.Sum[float64])
+	if !sum.IsMonotonic || sum.DataPoints[0].Value != 10 {
+		t.Fatalf("expected a monotonic sum of 10, got %+v", sum)
+	}
+
+	if err := gaugeReg.Unregister(); err != nil {
+		t.Fatalf("Unregister: %v", err)
+	}
+	if err := gaugeReg.Unregister(); err != nil {
+		t.Fatalf("second Unregister: %v", err)
+	}
+	_ = counterReg.Unregister()
+	var rm metricdata.ResourceMetrics
+	if err := reader.Collect(context.Background(), &rm); err != nil {
+		t.Fatalf("collect: %v", err)
+	}
+	for _, sm := range rm.ScopeMetrics {
+		for _, m := range sm.Metrics {
+			if m.Name == "queue_depth" && len(m.Data.(metricdata.Gauge[float64]).DataPoints) > 0 {
+				t.Fatal("expected no observations after Unregister")
+			}
+		}
+	}
+
+	if _, err := metrics.RegisterObservableGauge("nil_cb", mt.MetricTypeTech, mt.MetricClassInstance, nil); !errors.Is(err, mt.ErrNilCallback) {
+		t.Fatalf("expected ErrNilCallback, got %v", err)
+	}
+}
+
 func TestMetricReuseWithSameKey(t *testing.T) {
 	provider := sdkmetric.NewMeterProvider()
 	meter := provider.Meter("test-service")
@@ -160,21 +412,125 @@ func TestWithAttributes(t *testing.T) {
 }
 
 func TestWithAttributesFromContext(t *testing.T) {
-	provider := sdkmetric.NewMeterProvider()
-	meter := provider.Meter("test-service")
-	metrics := backend.NewMetrics(meter)
+	reader := sdkmetric.NewManualReader()
+	provider := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+	metrics := backend.NewMetrics(provider.Meter("test-service"))
 
-	ctx := context.Background()
+	ctx := mt.ContextWithTenantID(context.Background(), "acme")
+	ctx = mt.ContextWithRoute(ctx, "/users/:id")
+
+	counter := metrics.WithAttributesFromContext(ctx).GetOrCreateCounter("ctx_counter", mt.MetricTypeTech, mt.MetricClassService)
+	counter.Inc(ctx, mt.Attr("from_context", "true"))
 
-	// Test with context (currently returns self in implementation)
-	metricsFromCtx := metrics.WithAttributesFromContext(ctx)
-	if metricsFromCtx == nil {
-		t.Fatal("expected metrics from context")
+	sum := collect(t, reader, "ctx_counter").Data.(metricdata.Sum[int64])
+	set := sum.DataPoints[0].Attributes
+	for k, want := range map[string]string{mt.TenantIDAttr: "acme", mt.RouteAttr: "/users/:id", "from_context": "true"} {
+		if v, ok := set.Value(attribute.Key(k)); !ok || v.AsString() != want {
+			t.Fatalf("expected %s=%q, got %v", k, want, set.ToSlice())
+		}
+	}
+	if _, ok := set.Value(mt.ClientIDAttr); ok {
+		t.Fatalf("client_id must not be set without a client id in the context")
 	}
 
-	// Should work normally
-	counter := metricsFromCtx.GetOrCreateCounter("ctx_counter", mt.MetricTypeTech, mt.MetricClassService)
-	counter.Inc(ctx, mt.Attr("from_context", "true"))
+	// Without extracted attributes the same facade is returned.
+	if metrics.WithAttributesFromContext(context.Background()) != metrics {
+		t.Fatal("expected the same facade for a context without attributes")
+	}
+}
+
+// countingMeter counts the counters created through it.
+type countingMeter struct {
+	metric.Meter
+	counters int
+}
+
+func (m *countingMeter) Int64Counter(name string, opts ...metric.Int64CounterOption) (metric.Int64Counter, error) {
+	m.counters++
+	return m.Meter.Int64Counter(name, opts...)
+}
+
+func TestDerivedFacadesShareInstruments(t *testing.T) {
+	reader := sdkmetric.NewManualReader()
+	provider := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+	meter := &countingMeter{Meter: provider.Meter("test-service")}
+	metrics := backend.NewMetricsWithAttributes(meter, []mt.Attribute{mt.Attr("service", "orders")})
+
+	metrics.GetOrCreateCounter("requests_total", mt.MetricTypeTech, mt.MetricClassService).Inc(context.Background())
+	for _, tenant := range []string{"acme", "globex"} {
+		ctx := mt.ContextWithTenantID(context.Background(), tenant)
+		metrics.WithAttributesFromContext(ctx).
+			GetOrCreateCounter("requests_total", mt.MetricTypeTech, mt.MetricClassService).Inc(ctx)
+	}
+	if meter.counters != 1 {
+		t.Fatalf("expected the counter to be created once, got %d", meter.counters)
+	}
+
+	sum := collect(t, reader, "requests_total").Data.(metricdata.Sum[int64])
+	if len(sum.DataPoints) != 3 {
+		t.Fatalf("expected one series per attribute set, got %d", len(sum.DataPoints))
+	}
+	for _, dp := range sum.DataPoints {
+		if v, _ := dp.Attributes.Value("service"); v.AsString() != "orders" {
+			t.Fatalf("expected the base attributes on every series, got %v", dp.Attributes.ToSlice())
+		}
+	}
+}
+
+func TestHistogramExemplarsCarryTrace(t *testing.T) {
+	const (
+		tid = "4bf92f3577b34da6a3ce929d0e0e4736"
+		sid = "00f067aa0ba902b7"
+	)
+	reader := sdkmetric.NewManualReader()
+	provider := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+	metrics := backend.NewMetrics(provider.Meter("test-service"))
+	histogram := metrics.GetOrCreateHistogram("latency_seconds", mt.MetricTypeTech, mt.MetricClassService)
+
+	ctx := trace.ContextWithSpan(trace.ContextWithTrace(context.Background(), tid), sid)
+	histogram.Record(ctx, 2.5)
+
+	hist := collect(t, reader, "latency_seconds").Data.(metricdata.Histogram[float64])
+	exemplars := hist.DataPoints[0].Exemplars
+	if len(exemplars) != 1 {
+		t.Fatalf("expected one exemplar, got %d", len(exemplars))
+	}
+	if got := hex.EncodeToString(exemplars[0].TraceID); got != tid {
+		t.Fatalf("expected exemplar trace id %s, got %s", tid, got)
+	}
+	if got := hex.EncodeToString(exemplars[0].SpanID); got != sid {
+		t.Fatalf("expected exemplar span id %s, got %s", sid, got)
+	}
+
+	// Unsampled traces and legacy (non W3C) ids produce no exemplar.
+	reader = sdkmetric.NewManualReader()
+	provider = sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+	histogram = backend.NewMetrics(provider.Meter("test-service")).
+		GetOrCreateHistogram("latency_seconds", mt.MetricTypeTech, mt.MetricClassService)
+	histogram.Record(trace.ContextWithSampled(ctx, false), 1)
+	histogram.Record(trace.ContextWithTrace(context.Background(), "legacy-id"), 1)
+	hist = collect(t, reader, "latency_seconds").Data.(metricdata.Histogram[float64])
+	if n := len(hist.DataPoints[0].Exemplars); n != 0 {
+		t.Fatalf("expected no exemplars, got %d", n)
+	}
+}
+
+// collect reads the metrics of reader and returns the one named name.
+func collect(t *testing.T, reader sdkmetric.Reader, name string) metricdata.Metrics {
+	t.Helper()
+	var rm metricdata.ResourceMetrics
+	if err := reader.Collect(context.Background(), &rm); err != nil {
+		t.Fatalf("collect: %v", err)
+	}
+	for _, sm := range rm.ScopeMetrics {
+		for _, m := range sm.Metrics {
+			if m.Name == name {
+				return m
+			}
+		}
+	}
+	t.Fatalf("metric %s not collected", name)
+	return metricdata.Metrics{}
 }
 
 func TestAttributeCombination(t *testing.T) {
@@ -0,0 +1,57 @@
+package backend
+
+import (
+	"sync"
+
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+
+	mt "github.com/totvs/go-sdk/metrics"
+)
+
+// Default exponential histogram limits (same as the OpenTelemetry SDK).
+const (
+	defaultExponentialMaxSize  = 160
+	defaultExponentialMaxScale = 20
+)
+
+// Views records the aggregations requested through metric options
+// (mt.WithExponentialBuckets) by the facades of one MeterProvider. Instrument
+// options cannot select an aggregation, so View applies it when the SDK
+// creates the instrument. Each provider gets its own Views, installed with
+// sdkmetric.WithView(views.View) and passed to the facades built on it.
+type Views struct {
+	exponential sync.Map // map[string]mt.ExponentialBuckets
+}
+
+// NewViews creates an empty Views.
+func NewViews() *Views {
+	return &Views{}
+}
+
+// View is an OpenTelemetry SDK view applying the recorded aggregations;
+// instruments without such options are left to the next views.
+func (v *Views) View(inst sdkmetric.Instrument) (sdkmetric.Stream, bool) {
+	if inst.Kind != sdkmetric.InstrumentKindHistogram {
+		return sdkmetric.Stream{}, false
+	}
+	e, ok := v.exponential.Load(inst.Name)
+	if !ok {
+		return sdkmetric.Stream{}, false
+	}
+	exp := e.(mt.ExponentialBuckets)
+	if exp.MaxSize <= 0 {
+		exp.MaxSize = defaultExponentialMaxSize
+	}
+	if exp.MaxScale <= 0 {
+		exp.MaxScale = defaultExponentialMaxScale
+	}
+	return sdkmetric.Stream{
+		Name:        inst.Name,
+		Description: inst.Description,
+		Unit:        inst.Unit,
+		Aggregation: sdkmetric.AggregationBase2ExponentialHistogram{
+			MaxSize:  exp.MaxSize,
+			MaxScale: exp.MaxScale,
+		},
+	}, true
+}
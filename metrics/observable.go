@@ -0,0 +1,42 @@
+package metrics
+
+import (
+	"context"
+	"errors"
+)
+
+// ErrNilCallback is returned when an observable metric is registered without
+// a callback.
+var ErrNilCallback = errors.New("metrics: nil observable callback")
+
+// Observer receives the values of an observable metric during a collection.
+// Observe may be called once per attribute set.
+type Observer interface {
+	Observe(value float64, attrs ...Attribute)
+}
+
+// ObservableCallback reports the current values of an observable metric. It
+// is called on every collection (Prometheus scrape or periodic export), so it
+// must be fast and safe for concurrent use; a returned error is reported to
+// the OpenTelemetry error handler and the observed values are still exported.
+type ObservableCallback func(ctx context.Context, o Observer) error
+
+// Registration is the handle returned for an observable metric.
+type Registration interface {
+	// Unregister stops calling the callback. It is safe to call more than once.
+	Unregister() error
+}
+
+// RegisterObservableGauge registers an observable gauge in the global metrics.
+func RegisterObservableGauge(name string, metricType MetricType, metricClass MetricClass, callback ObservableCallback, opts ...MetricOption) (Registration, error) {
+	return GetGlobal().RegisterObservableGauge(name, metricType, metricClass, callback, opts...)
+}
+
+// RegisterObservableCounter registers an observable counter in the global metrics.
+func RegisterObservableCounter(name string, metricType MetricType, metricClass MetricClass, callback ObservableCallback, opts ...MetricOption) (Registration, error) {
+	return GetGlobal().RegisterObservableCounter(name, metricType, metricClass, callback, opts...)
+}
+
+type nopRegistration struct{}
+
+func (nopRegistration) Unregister() error { return nil }
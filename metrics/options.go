@@ -0,0 +1,73 @@
+package metrics
+
+// MetricConfig holds the optional descriptors of a metric, set through
+// MetricOption values passed to GetOrCreateCounter/Gauge/Histogram.
+type MetricConfig struct {
+	// Description is exported as the metric help text.
+	Description string
+	// Unit is the UCUM unit of the values, e.g. "s", "ms", "By" or "{request}".
+	Unit string
+	// Buckets are the explicit histogram bucket boundaries, in increasing order.
+	Buckets []float64
+	// Exponential selects an exponential (base-2) histogram; Buckets is then ignored.
+	Exponential *ExponentialBuckets
+}
+
+// ExponentialBuckets configures an exponential histogram: MaxSize is the
+// maximum number of buckets of each sign and MaxScale the maximum resolution.
+// Zero values use the OpenTelemetry defaults (160 and 20).
+type ExponentialBuckets struct {
+	MaxSize  int32
+	MaxScale int32
+}
+
+// MetricOption sets a descriptor of a metric. Descriptors are applied when the
+// metric is first created; later GetOrCreate calls for the same metric return
+// the cached instance and ignore their options.
+type MetricOption func(*MetricConfig)
+
+// NewMetricConfig applies opts over an empty configuration. It is used by
+// the MetricsFacade implementations.
+func NewMetricConfig(opts ...MetricOption) MetricConfig {
+	var cfg MetricConfig
+	for _, opt := range opts {
+		if opt != nil {
+			opt(&cfg)
+		}
+	}
+	return cfg
+}
+
+// WithDescription sets the help text of the metric.
+func WithDescription(description string) MetricOption {
+	return func(c *MetricConfig) { c.Description = description }
+}
+
+// WithUnit sets the UCUM unit of the metric values (the Prometheus exporter
+// adds the matching suffix, e.g. "s" -> "_seconds", unless the name has it).
+func WithUnit(unit string) MetricOption {
+	return func(c *MetricConfig) { c.Unit = unit }
+}
+
+// WithBuckets sets explicit bucket boundaries for a histogram, e.g. for
+// millisecond latencies:
+//
+//	metrics.WithBuckets(5, 10, 25, 50, 100, 250, 500, 1000, 2500)
+//
+// It is ignored by counters and gauges.
+func WithBuckets(boundaries ...float64) MetricOption {
+	return func(c *MetricConfig) {
+		c.Buckets = append([]float64(nil), boundaries...)
+	}
+}
+
+// WithExponentialBuckets makes a histogram use exponential (base-2) buckets,
+// which adapt to the recorded range without choosing boundaries. The
+// MeterProvider must install the views of the facade (the adapter setups do,
+// see adapter.NewMetricsWithViews); otherwise WithBuckets or the default
+// buckets are used. The Prometheus exporter exposes them as native histograms.
+func WithExponentialBuckets(maxSize, maxScale int32) MetricOption {
+	return func(c *MetricConfig) {
+		c.Exponential = &ExponentialBuckets{MaxSize: maxSize, MaxScale: maxScale}
+	}
+}
@@ -0,0 +1,210 @@
+package metrics_test
+
+import (
+	"context"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	collectormetrics "go.opentelemetry.io/proto/otlp/collector/metrics/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/proto"
+
+	mt "github.com/totvs/go-sdk/metrics"
+	"github.com/totvs/go-sdk/metrics/adapter"
+)
+
+// otlpReceiver is an in-process OTLP collector keeping the received requests.
+type otlpReceiver struct {
+	collectormetrics.UnimplementedMetricsServiceServer
+
+	mu       sync.Mutex
+	requests []*collectormetrics.ExportMetricsServiceRequest
+}
+
+func (r *otlpReceiver) Export(_ context.Context, req *collectormetrics.ExportMetricsServiceRequest) (*collectormetrics.ExportMetricsServiceResponse, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.requests = append(r.requests, req)
+	return &collectormetrics.ExportMetricsServiceResponse{}, nil
+}
+
+// ServeHTTP implements the OTLP/HTTP protobuf endpoint.
+func (r *otlpReceiver) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	if req.URL.Path != "/v1/metrics" {
+		http.NotFound(w, req)
+		return
+	}
+	body, _ := io.ReadAll(req.Body)
+	var msg collectormetrics.ExportMetricsServiceRequest
+	if err := proto.Unmarshal(body, &msg); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	_, _ = r.Export(req.Context(), &msg)
+	out, _ := proto.Marshal(&collectormetrics.ExportMetricsServiceResponse{})
+	w.Header().Set("Content-Type", "application/x-protobuf")
+	_, _ = w.Write(out)
+}
+
+// counterValue returns the last value received for the counter name and the
+// service.name of its resource.
+func (r *otlpReceiver) counterValue(name string) (value float64, service string, ok bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, req := range r.requests {
+		for _, rm := range req.GetResourceMetrics() {
+			for _, attr := range rm.GetResource().GetAttributes() {
+				if attr.GetKey() == "service.name" {
+					service = attr.GetValue().GetStringValue()
+				}
+			}
+			for _, sm := range rm.GetScopeMetrics() {
+				for _, m := range sm.GetMetrics() {
+					if m.GetName() != name {
+						continue
+					}
+					for _, dp := range m.GetSum().GetDataPoints() {
+						value, ok = float64(dp.GetAsInt()), true
+					}
+				}
+			}
+		}
+	}
+	return value, service, ok
+}
+
+func TestOTLPExport(t *testing.T) {
+	t.Run("HTTPFromEnv", func(t *testing.T) {
+		receiver := &otlpReceiver{}
+		server := httptest.NewServer(receiver)
+		defer server.Close()
+
+		t.Setenv("OTEL_EXPORTER_OTLP_ENDPOINT", server.URL)
+		t.Setenv("OTEL_EXPORTER_OTLP_PROTOCOL", "http/protobuf")
+		otlp, ok := adapter.OTLPConfigFromEnv()
+		if !ok {
+			t.Fatal("expected the OTLP endpoint env var to enable OTLP")
+		}
+
+		setup, err := adapter.NewOTLPMetrics(adapter.TOTVSMetricsConfig{ServiceName: "otlp-job", Platform: "totvs.apps", OTLP: &otlp})
+		if err != nil {
+			t.Fatalf("failed to create metrics: %v", err)
+		}
+		setup.Metrics.GetOrCreateCounter("jobs_processed", mt.MetricTypeTech, mt.MetricClassService).Add(context.Background(), 3)
+
+		// Shutdown pushes the pending measurements.
+		if err := setup.Shutdown(); err != nil {
+			t.Fatalf("shutdown: %v", err)
+		}
+		value, service, ok := receiver.counterValue("jobs_processed")
+		if !ok || value != 3 || service != "otlp-job" {
+			t.Fatalf("expected jobs_processed=3 from otlp-job, got %v (%q, received=%v)", value, service, ok)
+		}
+
+		rec := httptest.NewRecorder()
+		setup.Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+		if rec.Code != http.StatusNotFound {
+			t.Fatalf("expected 404 without Prometheus, got %d", rec.Code)
+		}
+	})
+
+	t.Run("GRPCWithPrometheus", func(t *testing.T) {
+		receiver := &otlpReceiver{}
+		lis, err := net.Listen("tcp", "127.0.0.1:0")
+		if err != nil {
+			t.Fatalf("listen: %v", err)
+		}
+		server := grpc.NewServer()
+		collectormetrics.RegisterMetricsServiceServer(server, receiver)
+		go func() { _ = server.Serve(lis) }()
+		defer server.Stop()
+
+		setup, err := adapter.NewDefaultMetrics(adapter.TOTVSMetricsConfig{
+			ServiceName: "otlp-service",
+			Platform:    "totvs.apps",
+			OTLP: &adapter.OTLPConfig{
+				Protocol:    adapter.OTLPProtocolGRPC,
+				EndpointURL: "http://" + lis.Addr().String(),
+				Interval:    time.Hour,
+			},
+		})
+		if err != nil {
+			t.Fatalf("failed to create metrics: %v", err)
+		}
+		defer setup.Shutdown()
+
+		setup.Metrics.GetOrCreateCounter("orders_total", mt.MetricTypeBusiness, mt.MetricClassService).Inc(context.Background())
+
+		if err := setup.ForceFlush(context.Background()); err != nil {
+			t.Fatalf("force flush: %v", err)
+		}
+		if value, _, ok := receiver.counterValue("orders_total"); !ok || value != 1 {
+			t.Fatalf("expected orders_total=1 over OTLP, got %v (received=%v)", value, ok)
+		}
+
+		// The same provider still serves the Prometheus endpoint.
+		rec := httptest.NewRecorder()
+		setup.Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+		if !strings.Contains(rec.Body.String(), "orders_total{") {
+			t.Fatalf("expected orders_total in the Prometheus output:\n%s", rec.Body.String())
+		}
+	})
+
+	t.Run("PrometheusWithOTLP", func(t *testing.T) {
+		receiver := &otlpReceiver{}
+		server := httptest.NewServer(receiver)
+		defer server.Close()
+
+		setup, err := adapter.NewPrometheusMetrics("otlp-simple", adapter.WithOTLP(adapter.OTLPConfig{
+			Protocol:    adapter.OTLPProtocolHTTP,
+			EndpointURL: server.URL,
+			Interval:    time.Hour,
+		}))
+		if err != nil {
+			t.Fatalf("failed to create metrics: %v", err)
+		}
+		defer setup.Shutdown()
+
+		setup.Metrics.GetOrCreateCounter("tasks_total", mt.MetricTypeTech, mt.MetricClassService).Add(context.Background(), 2)
+		if err := setup.ForceFlush(context.Background()); err != nil {
+			t.Fatalf("force flush: %v", err)
+		}
+		if value, service, ok := receiver.counterValue("tasks_total"); !ok || value != 2 || service != "otlp-simple" {
+			t.Fatalf("expected tasks_total=2 from otlp-simple, got %v (%q, received=%v)", value, service, ok)
+		}
+	})
+
+	t.Run("ResourceOnlyWithOTLP", func(t *testing.T) {
+		setup, err := adapter.NewDefaultMetrics(adapter.TOTVSMetricsConfig{ServiceName: "prom-only", Platform: "totvs.apps"})
+		if err != nil {
+			t.Fatalf("failed to create metrics: %v", err)
+		}
+		defer setup.Shutdown()
+		setup.Metrics.GetOrCreateCounter("hits_total", mt.MetricTypeTech, mt.MetricClassService).Inc(context.Background())
+
+		rec := httptest.NewRecorder()
+		setup.Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+		for _, line := range strings.Split(rec.Body.String(), "\n") {
+			if strings.HasPrefix(line, "target_info{") && strings.Contains(line, `service_name="prom-only"`) {
+				t.Fatalf("expected the default resource without OTLP, got %s", line)
+			}
+		}
+	})
+
+	t.Run("UnsupportedProtocol", func(t *testing.T) {
+		_, err := adapter.NewOTLPMetrics(adapter.TOTVSMetricsConfig{
+			ServiceName: "otlp-job",
+			Platform:    "totvs.apps",
+			OTLP:        &adapter.OTLPConfig{Protocol: "http/json"},
+		})
+		if err == nil || !strings.Contains(err.Error(), "unsupported OTLP protocol") {
+			t.Fatalf("expected an unsupported protocol error, got %v", err)
+		}
+	})
+}
@@ -0,0 +1,124 @@
+package adapter
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	oteltrace "go.opentelemetry.io/otel/trace"
+)
+
+type TOTVSTracingConfig struct {
+	ServiceName string // ServiceName is the name of the service generating spans
+	Platform    string // Examples: "totvs.apps", "erp.protheus", "fluig.apps", "carol.apps"
+	// Sampler decides which traces are recorded. Defaults to
+	// ParentBased(AlwaysSample), honoring the sampled flag of traceparent.
+	Sampler sdktrace.Sampler
+}
+
+// Validate checks if the TOTVS configuration has valid values
+func (c TOTVSTracingConfig) Validate() error {
+	if c.ServiceName == "" {
+		return fmt.Errorf("ServiceName is required")
+	}
+
+	if c.Platform == "" {
+		return fmt.Errorf("platform is required (example: totvs.apps)")
+	}
+
+	return nil
+}
+
+// DefaultTracingSetup contains the tracer provider created by the default setup.
+type DefaultTracingSetup struct {
+	Provider     *sdktrace.TracerProvider
+	shutdownOnce sync.Once
+	serviceName  string
+}
+
+// ServiceName returns the service name used in the setup.
+func (s *DefaultTracingSetup) ServiceName() string {
+	return s.serviceName
+}
+
+// Tracer returns a tracer named after the service.
+func (s *DefaultTracingSetup) Tracer() oteltrace.Tracer {
+	return s.Provider.Tracer(s.serviceName)
+}
+
+// SetGlobal registers the provider as the OpenTelemetry global tracer provider
+// and installs the W3C trace context and baggage propagators.
+func (s *DefaultTracingSetup) SetGlobal() {
+	otel.SetTracerProvider(s.Provider)
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+}
+
+// ForceFlush exports the spans still buffered by the provider.
+func (s *DefaultTracingSetup) ForceFlush(ctx context.Context) error {
+	return s.Provider.ForceFlush(ctx)
+}
+
+// Shutdown flushes pending spans and shuts down the tracer provider.
+func (s *DefaultTracingSetup) Shutdown() error {
+	var err error
+	s.shutdownOnce.Do(func() {
+		if s.Provider != nil {
+			err = s.Provider.Shutdown(context.Background())
+		}
+	})
+	return err
+}
+
+// NewDefaultTracing cria um TracerProvider com o recurso do serviço
+// (service.name e platform) exportando os spans em lote para exporter.
+// Com exporter nil os spans são criados (e seus ids aparecem nos logs), mas
+// não são exportados.
+func NewDefaultTracing(cfg TOTVSTracingConfig, exporter sdktrace.SpanExporter) (*DefaultTracingSetup, error) {
+	var opts []sdktrace.TracerProviderOption
+	if exporter != nil {
+		opts = append(opts, sdktrace.WithBatcher(exporter))
+	}
+	return newTracing(cfg, opts...)
+}
+
+// NewInMemoryTracing cria um TracerProvider para testes que exporta cada span
+// de forma síncrona para o InMemoryExporter retornado.
+func NewInMemoryTracing(serviceName string) (*DefaultTracingSetup, *tracetest.InMemoryExporter, error) {
+	exporter := tracetest.NewInMemoryExporter()
+	setup, err := newTracing(TOTVSTracingConfig{ServiceName: serviceName, Platform: "test"}, sdktrace.WithSyncer(exporter))
+	if err != nil {
+		return nil, nil, err
+	}
+	return setup, exporter, nil
+}
+
+func newTracing(cfg TOTVSTracingConfig, opts ...sdktrace.TracerProviderOption) (*DefaultTracingSetup, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid TOTVS configuration: %w", err)
+	}
+
+	res, err := resource.Merge(resource.Default(), resource.NewSchemaless(
+		attribute.String("service.name", cfg.ServiceName),
+		attribute.String("platform", cfg.Platform),
+	))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create tracing resource: %w", err)
+	}
+
+	sampler := cfg.Sampler
+	if sampler == nil {
+		sampler = sdktrace.ParentBased(sdktrace.AlwaysSample())
+	}
+	opts = append(opts, sdktrace.WithResource(res), sdktrace.WithSampler(sampler))
+
+	return &DefaultTracingSetup{
+		Provider:    sdktrace.NewTracerProvider(opts...),
+		serviceName: cfg.ServiceName,
+	}, nil
+}
@@ -0,0 +1,174 @@
+package trace
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	"go.opentelemetry.io/otel/baggage"
+)
+
+// BaggageHeader is the W3C baggage header name.
+const BaggageHeader = "baggage"
+
+// AnnotationPrefix qualifies the keys written by AnnotationCarrier, since
+// Kubernetes annotations must not collide with other tools.
+const AnnotationPrefix = "trace.totvs.com/"
+
+// Carrier stores propagation fields. It matches
+// go.opentelemetry.io/otel/propagation.TextMapCarrier, so carriers of this
+// package can be used with OpenTelemetry propagators and vice versa.
+type Carrier interface {
+	Get(key string) string
+	Set(key, value string)
+	Keys() []string
+}
+
+// MapCarrier is a Carrier backed by a map, for message headers or job
+// payloads. Keys are written in lower case and read case-insensitively.
+type MapCarrier map[string]string
+
+func (c MapCarrier) Get(key string) string {
+	if v, ok := c[key]; ok {
+		return v
+	}
+	if v, ok := c[strings.ToLower(key)]; ok {
+		return v
+	}
+	for k, v := range c {
+		if strings.EqualFold(k, key) {
+			return v
+		}
+	}
+	return ""
+}
+
+func (c MapCarrier) Set(key, value string) { c[strings.ToLower(key)] = value }
+
+func (c MapCarrier) Keys() []string {
+	keys := make([]string, 0, len(c))
+	for k := range c {
+		keys = append(keys, k)
+	}
+	return keys
+}
+
+// HeaderCarrier adapts http.Header (or any MIME-style header map) to Carrier.
+// Repeated tracestate and baggage headers are combined, as W3C requires.
+type HeaderCarrier http.Header
+
+func (c HeaderCarrier) Get(key string) string {
+	if strings.EqualFold(key, TraceStateHeader) || strings.EqualFold(key, BaggageHeader) {
+		return strings.Join(http.Header(c).Values(key), ",")
+	}
+	return http.Header(c).Get(key)
+}
+
+func (c HeaderCarrier) Set(key, value string) { http.Header(c).Set(key, value) }
+
+func (c HeaderCarrier) Keys() []string {
+	keys := make([]string, 0, len(c))
+	for k := range c {
+		keys = append(keys, k)
+	}
+	return keys
+}
+
+// AnnotationCarrier stores the fields in Kubernetes annotations (or labels-like
+// maps) under AnnotationPrefix, e.g. "trace.totvs.com/traceparent".
+type AnnotationCarrier map[string]string
+
+func (c AnnotationCarrier) Get(key string) string {
+	return c[AnnotationPrefix+strings.ToLower(key)]
+}
+
+func (c AnnotationCarrier) Set(key, value string) {
+	c[AnnotationPrefix+strings.ToLower(key)] = value
+}
+
+func (c AnnotationCarrier) Keys() []string {
+	var keys []string
+	for k := range c {
+		if key, ok := strings.CutPrefix(k, AnnotationPrefix); ok {
+			keys = append(keys, key)
+		}
+	}
+	return keys
+}
+
+// Inject writes the trace of ctx to c: X-Request-Id, traceparent/tracestate
+// when the ids are in the W3C format, and the baggage.
+func Inject(ctx context.Context, c Carrier) {
+	if tid := TraceIDFromContext(ctx); tid != "" {
+		c.Set(TraceIDHTTPHeader, tid)
+		if p, ok := TraceParentFromContext(ctx); ok {
+			c.Set(TraceParentHeader, p.String())
+			if ts := TraceStateFromContext(ctx); ts != "" {
+				c.Set(TraceStateHeader, ts)
+			}
+		}
+	}
+	if b := baggage.FromContext(ctx); b.Len() > 0 {
+		c.Set(BaggageHeader, b.String())
+	}
+}
+
+// Extract returns ctx enriched with the trace and baggage stored in c, using
+// the same precedence as ExtractHTTPHeaders; the carried span becomes the
+// parent span and a new span id is set for the current unit of work. Legacy
+// ids rejected by the Validator are ignored. The boolean is false (and no
+// trace is set) when c carries no valid trace id.
+func Extract(ctx context.Context, c Carrier) (context.Context, bool) {
+	if b, err := baggage.Parse(c.Get(BaggageHeader)); err == nil && b.Len() > 0 {
+		ctx = baggage.ContextWithBaggage(ctx, b)
+	}
+	if p, err := ParseTraceParent(c.Get(TraceParentHeader)); err == nil {
+		ctx = ContextWithTrace(ctx, p.TraceID)
+		ctx = context.WithValue(ctx, parentSpanIDKey, p.SpanID)
+		ctx = ContextWithSampled(ctx, p.Sampled)
+		if ts := c.Get(TraceStateHeader); ts != "" && len(ts) <= maxTraceStateLen {
+			ctx = ContextWithTraceState(ctx, ts)
+		}
+		return ContextWithSpan(ctx, GenerateSpanID()), true
+	}
+	v := GetValidator()
+	for _, k := range []string{TraceIDHTTPHeader, TraceIDHTTPCorrelationHeader} {
+		if tid := c.Get(k); tid != "" && v.Validate(tid) == nil {
+			return ContextWithSpan(ContextWithTrace(ctx, tid), GenerateSpanID()), true
+		}
+	}
+	return ctx, false
+}
+
+// ContextWithBaggageValue returns ctx with the baggage member key=value added
+// (or replaced). Baggage is propagated by Inject and the HTTP helpers; the
+// error reports a member the W3C baggage limits do not allow.
+func ContextWithBaggageValue(ctx context.Context, key, value string) (context.Context, error) {
+	m, err := baggage.NewMemberRaw(key, value)
+	if err != nil {
+		return ctx, err
+	}
+	b, err := baggage.FromContext(ctx).SetMember(m)
+	if err != nil {
+		return ctx, err
+	}
+	return baggage.ContextWithBaggage(ctx, b), nil
+}
+
+// BaggageValue returns the value of the baggage member key, if any.
+func BaggageValue(ctx context.Context, key string) string {
+	return baggage.FromContext(ctx).Member(key).Value()
+}
+
+// BaggageFromContext returns the baggage members of ctx as a map.
+func BaggageFromContext(ctx context.Context) map[string]string {
+	members := baggage.FromContext(ctx).Members()
+	if len(members) == 0 {
+		return nil
+	}
+	out := make(map[string]string, len(members))
+	for _, m := range members {
+		out[m.Key()] = m.Value()
+	}
+	return out
+}
@@ -0,0 +1,77 @@
+package trace_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	mt "github.com/totvs/go-sdk/metrics"
+	"github.com/totvs/go-sdk/trace"
+)
+
+func TestCarriersRoundTrip(t *testing.T) {
+	h := http.Header{}
+	h.Set(trace.TraceParentHeader, "00-"+tid+"-"+sid+"-01")
+	h.Add(trace.BaggageHeader, "tenant=acme")
+	h.Add(trace.BaggageHeader, "plan=gold")
+	ctx := trace.ExtractHTTPHeaders(context.Background(), h)
+	if trace.BaggageValue(ctx, "tenant") != "acme" || trace.BaggageValue(ctx, "plan") != "gold" {
+		t.Fatalf("expected repeated baggage headers to be combined, got %v", trace.BaggageFromContext(ctx))
+	}
+	ctx, err := trace.ContextWithBaggageValue(ctx, "region", "br south")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	for name, c := range map[string]trace.Carrier{
+		"map":        trace.MapCarrier{},
+		"header":     trace.HeaderCarrier(http.Header{}),
+		"annotation": trace.AnnotationCarrier{"app.kubernetes.io/name": "worker"},
+	} {
+		trace.Inject(ctx, c)
+		got, ok := trace.Extract(context.Background(), c)
+		if !ok || trace.TraceIDFromContext(got) != tid || trace.ParentSpanIDFromContext(got) != trace.SpanIDFromContext(ctx) {
+			t.Fatalf("%s: trace not carried: %v", name, c.Keys())
+		}
+		if trace.BaggageValue(got, "region") != "br south" || trace.BaggageValue(got, "tenant") != "acme" {
+			t.Fatalf("%s: baggage not carried: %v", name, trace.BaggageFromContext(got))
+		}
+	}
+
+	a := trace.AnnotationCarrier{}
+	trace.Inject(ctx, a)
+	if a[trace.AnnotationPrefix+"traceparent"] == "" || len(a.Keys()) != 3 {
+		t.Fatalf("unexpected annotations %v", a)
+	}
+	if got := (trace.MapCarrier{"X-Request-Id": "legacy"}).Get(trace.TraceIDHTTPHeader); got != "legacy" {
+		t.Fatalf("expected case-insensitive lookup, got %q", got)
+	}
+	if _, ok := trace.Extract(context.Background(), trace.MapCarrier{}); ok {
+		t.Fatal("an empty carrier carries no trace")
+	}
+}
+
+type otherKey struct{}
+
+func TestDetach(t *testing.T) {
+	parent, cancel := context.WithCancel(context.Background())
+	ctx := trace.ContextWithSpan(trace.ContextWithTrace(parent, tid), sid)
+	ctx, _ = trace.ContextWithBaggageValue(ctx, "tenant", "acme")
+	ctx = mt.ContextWithMetrics(ctx, mt.GetGlobal())
+	ctx = context.WithValue(ctx, otherKey{}, "request scoped")
+	cancel()
+
+	d := trace.Detach(ctx)
+	if d.Err() != nil {
+		t.Fatal("a detached context must not be cancelled")
+	}
+	if trace.TraceIDFromContext(d) != tid || trace.SpanIDFromContext(d) != sid || trace.BaggageValue(d, "tenant") != "acme" {
+		t.Fatal("expected trace and baggage to be kept")
+	}
+	if _, ok := mt.MetricsFromContext(d); !ok {
+		t.Fatal("expected the metrics facade to be kept")
+	}
+	if d.Value(otherKey{}) != nil {
+		t.Fatal("unregistered values must not be copied")
+	}
+}
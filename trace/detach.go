@@ -0,0 +1,56 @@
+package trace
+
+import (
+	"context"
+	"sync"
+
+	"go.opentelemetry.io/otel/baggage"
+	oteltrace "go.opentelemetry.io/otel/trace"
+)
+
+var (
+	detachMu   sync.RWMutex
+	detachKeys = []interface{}{traceIDKey, spanIDKey, parentSpanIDKey, sampledKey, traceStateKey}
+)
+
+// RegisterDetachKey makes Detach copy the context value stored under key.
+// The log and metrics packages register their logger and facade keys, so a
+// detached context keeps logging and recording with the same instances.
+func RegisterDetachKey(key interface{}) {
+	detachMu.Lock()
+	defer detachMu.Unlock()
+	for _, k := range detachKeys {
+		if k == key {
+			return
+		}
+	}
+	detachKeys = append(detachKeys, key)
+}
+
+// Detach returns a new context that is never cancelled and has no deadline,
+// carrying only the trace, baggage, OpenTelemetry span context and the
+// registered values (logger, metrics facade, ...) of ctx. Use it for
+// fire-and-forget work started from a request, which must outlive it
+// without retaining the rest of the request context.
+//
+//	go sendAudit(trace.Detach(r.Context()), event)
+func Detach(ctx context.Context) context.Context {
+	out := context.Background()
+	if ctx == nil {
+		return out
+	}
+	detachMu.RLock()
+	for _, k := range detachKeys {
+		if v := ctx.Value(k); v != nil {
+			out = context.WithValue(out, k, v)
+		}
+	}
+	detachMu.RUnlock()
+	if sc := oteltrace.SpanContextFromContext(ctx); sc.IsValid() {
+		out = oteltrace.ContextWithSpanContext(out, sc)
+	}
+	if b := baggage.FromContext(ctx); b.Len() > 0 {
+		out = baggage.ContextWithBaggage(out, b)
+	}
+	return out
+}
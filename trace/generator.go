@@ -0,0 +1,106 @@
+package trace
+
+import (
+	"crypto/rand"
+	"encoding/binary"
+	mrand "math/rand/v2"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// IDGenerator returns a new trace id. It must be safe for concurrent use.
+type IDGenerator func() string
+
+var idGenerator atomic.Pointer[IDGenerator]
+
+// SetIDGenerator replaces the generator used by GenerateTraceID (and by the
+// middlewares for requests without a valid incoming id). nil restores
+// RandomID.
+func SetIDGenerator(g IDGenerator) {
+	if g == nil {
+		idGenerator.Store(nil)
+		return
+	}
+	idGenerator.Store(&g)
+}
+
+// RandomID returns a random 128-bit id as 32 lowercase hex characters (a
+// valid W3C trace id). It is the default generator.
+func RandomID() string {
+	b := make([]byte, 16)
+	randomBytes(b)
+	if allZeros(hexEncode(b)) {
+		b[15] = 1
+	}
+	return hexEncode(b)
+}
+
+// UUIDv7 returns a time-ordered RFC 9562 version 7 UUID encoded as 32
+// lowercase hex characters, without dashes, so it is also a valid W3C trace
+// id.
+func UUIDv7() string {
+	b := make([]byte, 16)
+	randomBytes(b[6:])
+	putMillis(b, time.Now())
+	b[6] = 0x70 | b[6]&0x0f // version 7
+	b[8] = 0x80 | b[8]&0x3f // RFC 9562 variant
+	return hexEncode(b)
+}
+
+// ULID returns a time-ordered ULID (26 Crockford base32 characters). ULIDs
+// pass the default validator but are not W3C trace ids, so they are only
+// propagated in X-Request-Id.
+func ULID() string {
+	b := make([]byte, 16)
+	randomBytes(b[6:])
+	putMillis(b, time.Now())
+	return encodeCrockford(b)
+}
+
+// putMillis stores the Unix time in milliseconds in the first 48 bits of b.
+func putMillis(b []byte, t time.Time) {
+	var ms [8]byte
+	binary.BigEndian.PutUint64(ms[:], uint64(t.UnixMilli()))
+	copy(b[:6], ms[2:])
+}
+
+// fallbackRand is only used when the system random source fails; it is
+// seeded from the runtime so ids never degrade to timestamps.
+var (
+	fallbackMu   sync.Mutex
+	fallbackRand = mrand.NewChaCha8(seed())
+)
+
+func seed() [32]byte {
+	var s [32]byte
+	for i := 0; i < len(s); i += 8 {
+		binary.LittleEndian.PutUint64(s[i:], mrand.Uint64())
+	}
+	return s
+}
+
+func randomBytes(b []byte) {
+	if _, err := rand.Read(b); err == nil {
+		return
+	}
+	fallbackMu.Lock()
+	defer fallbackMu.Unlock()
+	_, _ = fallbackRand.Read(b)
+}
+
+const crockford = "0123456789ABCDEFGHJKMNPQRSTVWXYZ"
+
+// encodeCrockford encodes 128 bits as 26 base32 characters (the first one
+// carries the 3 most significant bits).
+func encodeCrockford(b []byte) string {
+	hi := binary.BigEndian.Uint64(b[:8])
+	lo := binary.BigEndian.Uint64(b[8:])
+	out := make([]byte, 26)
+	for i := 25; i >= 0; i-- {
+		out[i] = crockford[lo&0x1f]
+		lo = lo>>5 | hi<<59
+		hi >>= 5
+	}
+	return string(out)
+}
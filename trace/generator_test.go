@@ -0,0 +1,99 @@
+package trace_test
+
+import (
+	"net/http"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/totvs/go-sdk/trace"
+)
+
+func TestGenerators(t *testing.T) {
+	uuid := regexp.MustCompile(`^[0-9a-f]{12}7[0-9a-f]{3}[89ab][0-9a-f]{15}$`)
+	ulid := regexp.MustCompile(`^[0-7][0-9A-HJKMNP-TV-Z]{25}$`)
+	cases := map[string]struct {
+		gen   trace.IDGenerator
+		valid func(string) bool
+		w3c   bool
+	}{
+		"random": {trace.RandomID, trace.IsValidTraceID, true},
+		"uuidv7": {trace.UUIDv7, uuid.MatchString, true},
+		"ulid":   {trace.ULID, ulid.MatchString, false},
+	}
+	for name, tc := range cases {
+		seen := map[string]bool{}
+		for i := 0; i < 1000; i++ {
+			id := tc.gen()
+			if !tc.valid(id) || seen[id] {
+				t.Fatalf("%s: invalid or repeated id %q", name, id)
+			}
+			seen[id] = true
+			if err := (trace.Validator{}).Validate(id); err != nil {
+				t.Fatalf("%s: generated id %q rejected by the default validator", name, id)
+			}
+			if trace.IsValidTraceID(id) != tc.w3c {
+				t.Fatalf("%s: unexpected W3C compatibility for %q", name, id)
+			}
+		}
+	}
+}
+
+func TestTimeOrderedGenerators(t *testing.T) {
+	// the timestamp is the first 12 hex (UUIDv7) or 10 base32 (ULID) characters.
+	for name, tc := range map[string]struct {
+		gen    trace.IDGenerator
+		prefix int
+	}{"uuidv7": {trace.UUIDv7, 12}, "ulid": {trace.ULID, 10}} {
+		a := tc.gen()
+		time.Sleep(2 * time.Millisecond)
+		if b := tc.gen(); strings.Compare(a[:tc.prefix], b[:tc.prefix]) >= 0 {
+			t.Fatalf("%s: expected the timestamp prefix to increase: %s then %s", name, a, b)
+		}
+	}
+}
+
+func TestSetIDGenerator(t *testing.T) {
+	trace.SetIDGenerator(func() string { return "fixed" })
+	got := trace.GenerateTraceID()
+	trace.SetIDGenerator(nil)
+	if got != "fixed" || !trace.IsValidTraceID(trace.GenerateTraceID()) {
+		t.Fatalf("unexpected generated ids %q / %q", got, trace.GenerateTraceID())
+	}
+}
+
+func TestValidator(t *testing.T) {
+	v := trace.Validator{MaxLength: 40}
+	for id, ok := range map[string]bool{
+		"req-123_abc.def:1":          true,
+		"":                           false,
+		strings.Repeat("a", 41):      false,
+		"<script>":                   false,
+		"id with spaces":             false,
+		"id\nnew-line":               false,
+		"01ARZ3NDEKTSV4RRFFQ69G5FAV": true,
+	} {
+		if (v.Validate(id) == nil) != ok {
+			t.Errorf("Validate(%q): expected ok=%v", id, ok)
+		}
+	}
+	w3c := trace.Validator{RequireW3C: true}
+	if w3c.Validate(tid) != nil || w3c.Validate("req-123") == nil {
+		t.Fatal("RequireW3C should only accept W3C trace ids")
+	}
+
+	h := http.Header{}
+	h.Set(trace.TraceIDHTTPHeader, strings.Repeat("x", 200))
+	h.Set(trace.TraceIDHTTPCorrelationHeader, "corr-1")
+	if trace.ValidateHTTPHeaders(h) == nil {
+		t.Fatal("expected the oversized id to be reported")
+	}
+	if got := trace.TraceIDFromContext(trace.ExtractHTTPHeaders(t.Context(), h)); got != "corr-1" {
+		t.Fatalf("expected the invalid id to be skipped, got %q", got)
+	}
+	h.Set(trace.TraceParentHeader, "00-"+tid+"-"+sid+"-01")
+	if trace.ValidateHTTPHeaders(h) != nil {
+		t.Fatal("a valid traceparent takes precedence over legacy headers")
+	}
+}
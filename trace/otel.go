@@ -0,0 +1,73 @@
+package trace
+
+import (
+	"context"
+
+	oteltrace "go.opentelemetry.io/otel/trace"
+)
+
+// otelSpanContext returns the span context of the active OpenTelemetry span,
+// or an invalid one when there is none.
+func otelSpanContext(ctx context.Context) oteltrace.SpanContext {
+	if ctx == nil {
+		return oteltrace.SpanContext{}
+	}
+	return oteltrace.SpanContextFromContext(ctx)
+}
+
+// RemoteSpanContext converts the trace stored in ctx (see ExtractHTTPHeaders)
+// into an OpenTelemetry remote span context. The caller span received in
+// traceparent becomes the parent; for a W3C compatible X-Request-Id without
+// traceparent the local span id is used, so the OpenTelemetry spans keep the
+// id logged as trace_id. The boolean is false when the trace id is not in the
+// W3C format.
+func RemoteSpanContext(ctx context.Context) (oteltrace.SpanContext, bool) {
+	tid, err := oteltrace.TraceIDFromHex(stringValue(ctx, traceIDKey))
+	if err != nil {
+		return oteltrace.SpanContext{}, false
+	}
+	parent := stringValue(ctx, parentSpanIDKey)
+	if parent == "" {
+		parent = stringValue(ctx, spanIDKey)
+	}
+	sid, err := oteltrace.SpanIDFromHex(parent)
+	if err != nil {
+		return oteltrace.SpanContext{}, false
+	}
+	cfg := oteltrace.SpanContextConfig{TraceID: tid, SpanID: sid, Remote: true}
+	if b, ok := ctx.Value(sampledKey).(bool); !ok || b {
+		cfg.TraceFlags = oteltrace.FlagsSampled
+	}
+	if ts, err := oteltrace.ParseTraceState(stringValue(ctx, traceStateKey)); err == nil {
+		cfg.TraceState = ts
+	}
+	return oteltrace.NewSpanContext(cfg), true
+}
+
+// OTelTraceIDFromContext returns the trace id of the active OpenTelemetry
+// span when TraceIDFromContext reports a different one (a legacy id received
+// by the service, see TraceIDFromContext), and "" otherwise.
+func OTelTraceIDFromContext(ctx context.Context) string {
+	sc := otelSpanContext(ctx)
+	if !sc.IsValid() {
+		return ""
+	}
+	if id := sc.TraceID().String(); id != TraceIDFromContext(ctx) {
+		return id
+	}
+	return ""
+}
+
+// ContextWithRemoteParent returns ctx carrying RemoteSpanContext(ctx) as the
+// remote parent, so spans started from it continue the incoming trace. ctx is
+// returned unchanged when it already has an active span or the trace id is
+// not in the W3C format.
+func ContextWithRemoteParent(ctx context.Context) context.Context {
+	if otelSpanContext(ctx).IsValid() {
+		return ctx
+	}
+	if sc, ok := RemoteSpanContext(ctx); ok {
+		return oteltrace.ContextWithRemoteSpanContext(ctx, sc)
+	}
+	return ctx
+}
@@ -0,0 +1,84 @@
+package trace_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/totvs/go-sdk/trace"
+	"github.com/totvs/go-sdk/trace/adapter"
+)
+
+func TestActiveOTelSpanTakesPrecedence(t *testing.T) {
+	setup, exporter, err := adapter.NewInMemoryTracing("orders")
+	if err != nil {
+		t.Fatalf("setup failed: %v", err)
+	}
+	defer setup.Shutdown()
+
+	h := http.Header{}
+	h.Set(trace.TraceParentHeader, "00-"+tid+"-"+sid+"-01")
+	h.Set(trace.TraceStateHeader, "congo=t61rcWkgMzE")
+	ctx := trace.ExtractHTTPHeaders(context.Background(), h)
+	local := trace.SpanIDFromContext(ctx)
+
+	ctx, span := setup.Tracer().Start(trace.ContextWithRemoteParent(ctx), "work")
+	sc := span.SpanContext()
+	if trace.TraceIDFromContext(ctx) != tid || trace.SpanIDFromContext(ctx) != sc.SpanID().String() || sc.SpanID().String() == local {
+		t.Fatalf("expected the OTel span ids, got %s / %s", trace.TraceIDFromContext(ctx), trace.SpanIDFromContext(ctx))
+	}
+	if trace.TraceStateFromContext(ctx) != "congo=t61rcWkgMzE" || !trace.SampledFromContext(ctx) {
+		t.Fatalf("unexpected tracestate/sampled: %q / %v", trace.TraceStateFromContext(ctx), trace.SampledFromContext(ctx))
+	}
+	span.End()
+
+	spans := exporter.GetSpans()
+	if len(spans) != 1 || spans[0].Parent.SpanID().String() != sid || !spans[0].Parent.IsRemote() {
+		t.Fatalf("expected one span child of the remote caller, got %+v", spans)
+	}
+}
+
+func TestRemoteParentFromLegacyID(t *testing.T) {
+	h := http.Header{}
+	h.Set(trace.TraceIDHTTPHeader, tid)
+	if _, ok := trace.RemoteSpanContext(trace.ExtractHTTPHeaders(context.Background(), h)); !ok {
+		t.Fatal("a W3C compatible X-Request-Id should continue as the OTel trace")
+	}
+	h.Set(trace.TraceIDHTTPHeader, "not-hex")
+	ctx := trace.ExtractHTTPHeaders(context.Background(), h)
+	if _, ok := trace.RemoteSpanContext(ctx); ok || trace.ContextWithRemoteParent(ctx) != ctx {
+		t.Fatal("legacy ids must not produce a remote parent")
+	}
+}
+
+func TestLegacyIDKeptUnderOTelSpan(t *testing.T) {
+	setup, _, err := adapter.NewInMemoryTracing("orders")
+	if err != nil {
+		t.Fatalf("setup failed: %v", err)
+	}
+	defer setup.Shutdown()
+
+	ctx := trace.ContextWithTrace(context.Background(), "legacy-abc-123")
+	ctx, span := setup.Tracer().Start(trace.ContextWithRemoteParent(ctx), "work")
+	defer span.End()
+	sc := span.SpanContext()
+
+	if got := trace.TraceIDFromContext(ctx); got != "legacy-abc-123" {
+		t.Fatalf("expected the legacy id to be kept, got %q", got)
+	}
+	if got := trace.OTelTraceIDFromContext(ctx); got != sc.TraceID().String() {
+		t.Fatalf("expected the OTel trace id, got %q", got)
+	}
+	h := http.Header{}
+	trace.InjectHTTPHeaders(ctx, h)
+	if h.Get(trace.TraceIDHTTPHeader) != "legacy-abc-123" || h.Get(trace.TraceParentHeader) != "00-"+sc.TraceID().String()+"-"+sc.SpanID().String()+"-01" {
+		t.Fatalf("expected the legacy id and the span traceparent, got %v", h)
+	}
+
+	// Without a legacy id both ids are the same and no extra id is reported.
+	ctx, child := setup.Tracer().Start(context.Background(), "other")
+	defer child.End()
+	if trace.OTelTraceIDFromContext(ctx) != "" {
+		t.Fatal("expected no separate OTel trace id for a W3C trace")
+	}
+}
@@ -2,8 +2,8 @@ package trace
 
 import (
 	"context"
-	"crypto/rand"
-	"time"
+
+	oteltrace "go.opentelemetry.io/otel/trace"
 )
 
 // Public constants for trace header and field names used across projects.
@@ -44,29 +44,36 @@ func LoggedFromContext(ctx context.Context) bool {
 	return false
 }
 
-// TraceIDFromContext extracts the trace id from the context, if present.
+// TraceIDFromContext extracts the trace id from the context, if present. The
+// trace id of an active OpenTelemetry span takes precedence, except over a
+// legacy (non W3C) id stored with ContextWithTrace: no span can carry it, so
+// it is kept and the span trace id is reported by OTelTraceIDFromContext.
 func TraceIDFromContext(ctx context.Context) string {
 	if ctx == nil {
 		return ""
 	}
-	if v := ctx.Value(traceIDKey); v != nil {
-		if s, ok := v.(string); ok {
-			return s
-		}
+	id := stringValue(ctx, traceIDKey)
+	if id != "" && !IsValidTraceID(id) {
+		return id
+	}
+	if sc := oteltrace.SpanContextFromContext(ctx); sc.IsValid() {
+		return sc.TraceID().String()
 	}
-	return ""
+	return id
 }
 
-// GenerateTraceID returns a new 16-byte hex trace id.
-func GenerateTraceID() string { return generateTraceID() }
-
-func generateTraceID() string {
-	b := make([]byte, 16)
-	if _, err := rand.Read(b); err != nil {
-		return time.Now().UTC().Format("20060102T150405.000000000Z")
+// GenerateTraceID returns a new trace id from the configured generator
+// (RandomID, a 16-byte hex id, unless replaced with SetIDGenerator).
+func GenerateTraceID() string {
+	if g := idGenerator.Load(); g != nil {
+		return (*g)()
 	}
+	return RandomID()
+}
+
+func hexEncode(b []byte) string {
 	const hextable = "0123456789abcdef"
-	dst := make([]byte, 32)
+	dst := make([]byte, len(b)*2)
 	for i, v := range b {
 		dst[i*2] = hextable[v>>4]
 		dst[i*2+1] = hextable[v&0x0f]
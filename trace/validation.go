@@ -0,0 +1,93 @@
+package trace
+
+import (
+	"errors"
+	"net/http"
+	"sync/atomic"
+)
+
+// DefaultMaxIDLength is the maximum length of an incoming trace id accepted
+// by the default Validator.
+const DefaultMaxIDLength = 128
+
+// ErrInvalidTraceID reports an incoming trace id rejected by the Validator.
+var ErrInvalidTraceID = errors.New("trace: invalid trace id")
+
+// InvalidIDPolicy decides what the middlewares do with an invalid incoming id.
+type InvalidIDPolicy int
+
+const (
+	// RegenerateInvalidID ignores the invalid id and generates a new one (default).
+	RegenerateInvalidID InvalidIDPolicy = iota
+	// RejectInvalidID answers the request with 400 Bad Request.
+	RejectInvalidID
+)
+
+// Validator checks the trace ids received in X-Request-Id/X-Correlation-Id
+// (and carriers). Ids must be non-empty, at most MaxLength characters and
+// use only ASCII letters, digits, '-', '_', '.' and ':'; with RequireW3C
+// they must be W3C trace ids (32 lowercase hex characters).
+type Validator struct {
+	// MaxLength defaults to DefaultMaxIDLength when zero.
+	MaxLength  int
+	RequireW3C bool
+	Policy     InvalidIDPolicy
+}
+
+var validator atomic.Pointer[Validator]
+
+// SetValidator replaces the validator applied to incoming trace ids.
+func SetValidator(v Validator) { validator.Store(&v) }
+
+// GetValidator returns the validator applied to incoming trace ids.
+func GetValidator() Validator {
+	if v := validator.Load(); v != nil {
+		return *v
+	}
+	return Validator{}
+}
+
+// Validate returns ErrInvalidTraceID when id is not accepted.
+func (v Validator) Validate(id string) error {
+	max := v.MaxLength
+	if max <= 0 {
+		max = DefaultMaxIDLength
+	}
+	if id == "" || len(id) > max {
+		return ErrInvalidTraceID
+	}
+	if v.RequireW3C {
+		if !IsValidTraceID(id) {
+			return ErrInvalidTraceID
+		}
+		return nil
+	}
+	for i := 0; i < len(id); i++ {
+		c := id[i]
+		switch {
+		case c >= 'a' && c <= 'z', c >= 'A' && c <= 'Z', c >= '0' && c <= '9',
+			c == '-', c == '_', c == '.', c == ':':
+		default:
+			return ErrInvalidTraceID
+		}
+	}
+	return nil
+}
+
+// ValidateHTTPHeaders reports whether the trace ids sent in h are acceptable:
+// nil when a valid traceparent is present or every legacy id header sent is
+// valid, ErrInvalidTraceID otherwise.
+func ValidateHTTPHeaders(h http.Header) error {
+	if _, err := ParseTraceParent(h.Get(TraceParentHeader)); err == nil {
+		return nil
+	}
+	v := GetValidator()
+	for _, k := range []string{TraceIDHTTPHeader, TraceIDHTTPCorrelationHeader} {
+		if id := h.Get(k); id != "" {
+			if err := v.Validate(id); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,206 @@
+package trace
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+
+	oteltrace "go.opentelemetry.io/otel/trace"
+)
+
+// W3C Trace Context header and span field names.
+const (
+	TraceParentHeader = "traceparent"
+	TraceStateHeader  = "tracestate"
+	SpanIDField       = "span_id"
+	ParentSpanIDField = "parent_span_id"
+	// OTelTraceIDField holds the OpenTelemetry trace id when it differs from
+	// trace_id (see OTelTraceIDFromContext).
+	OTelTraceIDField = "otel_trace_id"
+)
+
+const (
+	spanIDKey       ctxKey = "span-id"
+	parentSpanIDKey ctxKey = "parent-span-id"
+	sampledKey      ctxKey = "sampled"
+	traceStateKey   ctxKey = "trace-state"
+)
+
+// maxTraceStateLen bounds the propagated tracestate; longer values are dropped
+// as allowed by the W3C specification.
+const maxTraceStateLen = 512
+
+// ErrInvalidTraceParent is returned by ParseTraceParent for malformed headers.
+var ErrInvalidTraceParent = errors.New("trace: invalid traceparent")
+
+// TraceParent is the decoded W3C traceparent header: a 32-hex trace id, the
+// 16-hex id of the caller span and the sampled flag.
+type TraceParent struct {
+	TraceID string
+	SpanID  string
+	Sampled bool
+}
+
+// ParseTraceParent decodes a traceparent header ("00-<trace-id>-<span-id>-<flags>").
+// Versions above 00 are accepted as long as the 00 fields are valid; version
+// ff and all-zero ids are rejected.
+func ParseTraceParent(s string) (TraceParent, error) {
+	s = strings.TrimSpace(s)
+	if len(s) < 55 || (len(s) > 55 && s[55] != '-') {
+		return TraceParent{}, ErrInvalidTraceParent
+	}
+	version, tid, sid, flags := s[0:2], s[3:35], s[36:52], s[53:55]
+	if s[2] != '-' || s[35] != '-' || s[52] != '-' || !isHex(version) || version == "ff" ||
+		(version == "00" && len(s) != 55) || !isHex(flags) ||
+		!IsValidTraceID(tid) || !IsValidSpanID(sid) {
+		return TraceParent{}, ErrInvalidTraceParent
+	}
+	return TraceParent{TraceID: tid, SpanID: sid, Sampled: unhex(flags[1])&1 == 1}, nil
+}
+
+// String encodes p as a version 00 traceparent header.
+func (p TraceParent) String() string {
+	flags := "00"
+	if p.Sampled {
+		flags = "01"
+	}
+	return "00-" + p.TraceID + "-" + p.SpanID + "-" + flags
+}
+
+// IsValidTraceID reports whether id is a W3C trace id (32 lowercase hex
+// characters, not all zeros).
+func IsValidTraceID(id string) bool { return len(id) == 32 && isHex(id) && !allZeros(id) }
+
+// IsValidSpanID reports whether id is a W3C span id (16 lowercase hex
+// characters, not all zeros).
+func IsValidSpanID(id string) bool { return len(id) == 16 && isHex(id) && !allZeros(id) }
+
+// GenerateSpanID returns a new random 8-byte hex span id.
+func GenerateSpanID() string {
+	b := make([]byte, 8)
+	randomBytes(b)
+	if allZeros(hexEncode(b)) {
+		b[7] = 1
+	}
+	return hexEncode(b)
+}
+
+// ContextWithSpan returns a new context containing the provided span id.
+func ContextWithSpan(ctx context.Context, spanID string) context.Context {
+	return context.WithValue(ctx, spanIDKey, spanID)
+}
+
+// SpanIDFromContext extracts the span id from the context, if present. The
+// span id of an active OpenTelemetry span takes precedence.
+func SpanIDFromContext(ctx context.Context) string {
+	if sc := otelSpanContext(ctx); sc.IsValid() {
+		return sc.SpanID().String()
+	}
+	return stringValue(ctx, spanIDKey)
+}
+
+// ParentSpanIDFromContext returns the id of the caller span received in the
+// traceparent header, if any.
+func ParentSpanIDFromContext(ctx context.Context) string { return stringValue(ctx, parentSpanIDKey) }
+
+// ContextWithSampled records the sampled flag propagated in traceparent.
+func ContextWithSampled(ctx context.Context, sampled bool) context.Context {
+	return context.WithValue(ctx, sampledKey, sampled)
+}
+
+// SampledFromContext returns the sampled flag (of the active OpenTelemetry
+// span, when present); it defaults to true when the context carries no
+// decision.
+func SampledFromContext(ctx context.Context) bool {
+	if ctx == nil {
+		return true
+	}
+	if sc := oteltrace.SpanContextFromContext(ctx); sc.IsValid() {
+		return sc.IsSampled()
+	}
+	if b, ok := ctx.Value(sampledKey).(bool); ok {
+		return b
+	}
+	return true
+}
+
+// ContextWithTraceState returns a new context containing the tracestate value
+// to be propagated unchanged.
+func ContextWithTraceState(ctx context.Context, state string) context.Context {
+	return context.WithValue(ctx, traceStateKey, state)
+}
+
+// TraceStateFromContext extracts the tracestate from the context (or from the
+// active OpenTelemetry span), if present.
+func TraceStateFromContext(ctx context.Context) string {
+	if sc := otelSpanContext(ctx); sc.IsValid() {
+		return sc.TraceState().String()
+	}
+	return stringValue(ctx, traceStateKey)
+}
+
+// TraceParentFromContext builds the traceparent for outgoing calls from the
+// active OpenTelemetry span or else the trace and span ids of ctx. The boolean
+// is false when either id is not in the W3C format (for example a legacy
+// X-Request-Id value without a span).
+func TraceParentFromContext(ctx context.Context) (TraceParent, bool) {
+	if sc := otelSpanContext(ctx); sc.IsValid() {
+		return TraceParent{TraceID: sc.TraceID().String(), SpanID: sc.SpanID().String(), Sampled: sc.IsSampled()}, true
+	}
+	p := TraceParent{TraceID: TraceIDFromContext(ctx), SpanID: SpanIDFromContext(ctx), Sampled: SampledFromContext(ctx)}
+	if !IsValidTraceID(p.TraceID) || !IsValidSpanID(p.SpanID) {
+		return TraceParent{}, false
+	}
+	return p, true
+}
+
+// ExtractHTTPHeaders returns ctx enriched with the trace and baggage received
+// in h and a new span id for the current hop. Precedence: a valid traceparent
+// (its span becomes the parent span and tracestate is kept), then a valid
+// X-Request-Id, then a valid X-Correlation-Id (see Validator), otherwise a new
+// trace id is generated.
+func ExtractHTTPHeaders(ctx context.Context, h http.Header) context.Context {
+	if ctx, ok := Extract(ctx, HeaderCarrier(h)); ok {
+		return ctx
+	}
+	return ContextWithSpan(ContextWithTrace(ctx, GenerateTraceID()), GenerateSpanID())
+}
+
+// InjectHTTPHeaders writes the trace of ctx to h: X-Request-Id,
+// traceparent/tracestate when the ids are in the W3C format, and baggage.
+func InjectHTTPHeaders(ctx context.Context, h http.Header) { Inject(ctx, HeaderCarrier(h)) }
+
+func stringValue(ctx context.Context, key ctxKey) string {
+	if ctx == nil {
+		return ""
+	}
+	s, _ := ctx.Value(key).(string)
+	return s
+}
+
+func isHex(s string) bool {
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if (c < '0' || c > '9') && (c < 'a' || c > 'f') {
+			return false
+		}
+	}
+	return true
+}
+
+func allZeros(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] != '0' {
+			return false
+		}
+	}
+	return true
+}
+
+func unhex(c byte) byte {
+	if c >= 'a' {
+		return c - 'a' + 10
+	}
+	return c - '0'
+}
@@ -0,0 +1,95 @@
+package trace_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/totvs/go-sdk/trace"
+)
+
+const (
+	tid = "4bf92f3577b34da6a3ce929d0e0e4736"
+	sid = "00f067aa0ba902b7"
+)
+
+func TestParseTraceParent(t *testing.T) {
+	p, err := trace.ParseTraceParent("00-" + tid + "-" + sid + "-01")
+	if err != nil || p.TraceID != tid || p.SpanID != sid || !p.Sampled {
+		t.Fatalf("unexpected result %+v / %v", p, err)
+	}
+	if p.String() != "00-"+tid+"-"+sid+"-01" {
+		t.Fatalf("unexpected encoding %s", p.String())
+	}
+	if p, err := trace.ParseTraceParent("cc-" + tid + "-" + sid + "-00-future"); err != nil || p.Sampled {
+		t.Fatalf("expected future versions to be accepted, got %+v / %v", p, err)
+	}
+
+	for _, bad := range []string{
+		"",
+		"00-" + tid + "-" + sid + "-01-extra", // version 00 has no extra fields
+		"ff-" + tid + "-" + sid + "-01",       // forbidden version
+		"00-00000000000000000000000000000000-" + sid + "-01", // zero trace id
+		"00-" + tid + "-0000000000000000-01",                 // zero span id
+		"00-4BF92F3577B34DA6A3CE929D0E0E4736-" + sid + "-01", // upper case
+		"00_" + tid + "_" + sid + "_01",
+	} {
+		if _, err := trace.ParseTraceParent(bad); err == nil {
+			t.Errorf("expected %q to be rejected", bad)
+		}
+	}
+}
+
+func TestExtractHTTPHeadersPrecedence(t *testing.T) {
+	h := http.Header{}
+	h.Set(trace.TraceIDHTTPHeader, "legacy-id")
+	h.Set(trace.TraceParentHeader, "00-"+tid+"-"+sid+"-00")
+	h.Add(trace.TraceStateHeader, "congo=t61rcWkgMzE")
+	h.Add(trace.TraceStateHeader, "rojo=00f067aa0ba902b7")
+
+	ctx := trace.ExtractHTTPHeaders(context.Background(), h)
+	if trace.TraceIDFromContext(ctx) != tid || trace.ParentSpanIDFromContext(ctx) != sid {
+		t.Fatalf("expected traceparent to win, got %s / %s", trace.TraceIDFromContext(ctx), trace.ParentSpanIDFromContext(ctx))
+	}
+	if span := trace.SpanIDFromContext(ctx); !trace.IsValidSpanID(span) || span == sid {
+		t.Fatalf("expected a new span id for this hop, got %q", span)
+	}
+	if trace.SampledFromContext(ctx) || trace.TraceStateFromContext(ctx) != "congo=t61rcWkgMzE,rojo=00f067aa0ba902b7" {
+		t.Fatalf("unexpected sampled/tracestate: %v / %q", trace.SampledFromContext(ctx), trace.TraceStateFromContext(ctx))
+	}
+
+	out := http.Header{}
+	trace.InjectHTTPHeaders(ctx, out)
+	p, err := trace.ParseTraceParent(out.Get(trace.TraceParentHeader))
+	if err != nil || p.TraceID != tid || p.SpanID != trace.SpanIDFromContext(ctx) || p.Sampled {
+		t.Fatalf("unexpected outgoing traceparent %q", out.Get(trace.TraceParentHeader))
+	}
+	if out.Get(trace.TraceIDHTTPHeader) != tid || out.Get(trace.TraceStateHeader) == "" {
+		t.Fatalf("unexpected outgoing headers %v", out)
+	}
+
+	h.Set(trace.TraceParentHeader, "garbage")
+	if got := trace.TraceIDFromContext(trace.ExtractHTTPHeaders(context.Background(), h)); got != "legacy-id" {
+		t.Fatalf("expected the legacy header when traceparent is invalid, got %q", got)
+	}
+	h.Del(trace.TraceIDHTTPHeader)
+	h.Set(trace.TraceIDHTTPCorrelationHeader, "corr-id")
+	if got := trace.TraceIDFromContext(trace.ExtractHTTPHeaders(context.Background(), h)); got != "corr-id" {
+		t.Fatalf("expected the correlation header, got %q", got)
+	}
+}
+
+func TestInjectHTTPHeadersLegacyID(t *testing.T) {
+	ctx := trace.ContextWithSpan(trace.ContextWithTrace(context.Background(), "legacy-id"), trace.GenerateSpanID())
+	if _, ok := trace.TraceParentFromContext(ctx); ok {
+		t.Fatal("legacy ids cannot form a traceparent")
+	}
+	out := http.Header{}
+	trace.InjectHTTPHeaders(ctx, out)
+	if out.Get(trace.TraceIDHTTPHeader) != "legacy-id" || out.Get(trace.TraceParentHeader) != "" {
+		t.Fatalf("unexpected headers %v", out)
+	}
+	if !trace.IsValidTraceID(trace.GenerateTraceID()) {
+		t.Fatal("generated trace ids must be W3C compatible")
+	}
+}
@@ -7,6 +7,8 @@ import (
 
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/suite"
+	logger "github.com/totvs/go-sdk/log"
+	"github.com/totvs/go-sdk/log/logtest"
 )
 
 // --- Mocks ---
@@ -135,13 +137,17 @@ func (s *PipelineTestSuite) TestShouldFailWhenTransmitterReturnsError() {
 
 	p := New("test-cluster", mockTransmitter)
 	p.AddStep(NewStep("test-step", mockCollector, mockProcessor))
+	rec := logtest.New()
 
 	// Act
-	err := p.Run(context.Background())
+	err := p.Run(logger.ContextWithLogger(context.Background(), rec))
 
 	// Assert
 	s.Error(err)
 	s.Contains(err.Error(), "transmission failed")
+	logtest.AssertLogged(s.T(), rec, logtest.Level(logger.ErrorLevel),
+		logtest.Message("[Pipeline] Transmission failed"), logtest.ErrContains("transmission error"))
+	logtest.AssertNotLogged(s.T(), rec, logtest.MessageContains("completed successfully for source"))
 }
 
 func (s *PipelineTestSuite) TestShouldExecuteMultipleSteps() {
@@ -180,13 +186,15 @@ func (s *PipelineTestSuite) TestShouldSkipWhenNoStepsRegistered() {
 	// Arrange
 	mockTransmitter := new(MockTransmitter)
 	p := New("test-cluster", mockTransmitter)
+	rec := logtest.New()
 
 	// Act
-	err := p.Run(context.Background())
+	err := p.Run(logger.ContextWithLogger(context.Background(), rec))
 
 	// Assert
 	s.NoError(err)
 	mockTransmitter.AssertNotCalled(s.T(), "Transmit")
+	logtest.AssertLogged(s.T(), rec, logtest.Level(logger.WarnLevel), logtest.MessageContains("No steps registered"))
 }
 
 func (s *PipelineTestSuite) TestShouldEnrichReportViaPostProcessor() {